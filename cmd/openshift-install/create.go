@@ -5,10 +5,13 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -28,12 +31,43 @@ import (
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	routeclient "github.com/openshift/client-go/route/clientset/versioned"
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	targetassets "github.com/openshift/installer/pkg/asset/targets"
 	destroybootstrap "github.com/openshift/installer/pkg/destroy/bootstrap"
+	"github.com/openshift/installer/pkg/events"
+	"github.com/openshift/installer/pkg/metrics"
+	"github.com/openshift/installer/pkg/redact"
 	cov1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
 )
 
+const (
+	defaultAPITimeout               = 30 * time.Minute
+	defaultBootstrapCompleteTimeout = 30 * time.Minute
+	defaultInstallCompleteTimeout   = 30 * time.Minute
+	defaultConsoleTimeout           = 10 * time.Minute
+)
+
+var (
+	createOpts struct {
+		lenient                    bool
+		releaseImageKeyring        string
+		releaseImageSignatureStore string
+	}
+
+	installConfigOpts struct {
+		fromCluster bool
+		kubeconfig  string
+	}
+
+	clusterOpts struct {
+		dryRun bool
+	}
+)
+
 type target struct {
 	name    string
 	command *cobra.Command
@@ -50,6 +84,19 @@ var (
 			Short: "Generates the Install Config asset",
 			// FIXME: add longer descriptions for our commands with examples for better UX.
 			// Long:  "",
+			Run: func(cmd *cobra.Command, args []string) {
+				if installConfigOpts.fromCluster {
+					cleanup := setupFileHook(rootOpts.dir)
+					defer cleanup()
+
+					err := runInstallConfigFromClusterCmd(rootOpts.dir, installConfigOpts.kubeconfig)
+					if err != nil {
+						logrus.Fatal(err)
+					}
+					return
+				}
+				runTargetCmd(targetassets.InstallConfig...)(cmd, args)
+			},
 		},
 		assets: targetassets.InstallConfig,
 	}
@@ -93,7 +140,24 @@ var (
 			Short: "Create an OpenShift cluster",
 			// FIXME: add longer descriptions for our commands with examples for better UX.
 			// Long:  "",
+			Run: func(cmd *cobra.Command, args []string) {
+				if clusterOpts.dryRun {
+					cleanup := setupFileHook(rootOpts.dir)
+					defer cleanup()
+
+					err := runPlanCmd(rootOpts.dir)
+					if err != nil {
+						logrus.Fatal(err)
+					}
+					return
+				}
+				runTargetCmd(targetassets.Cluster...)(cmd, args)
+			},
 			PostRun: func(_ *cobra.Command, _ []string) {
+				if clusterOpts.dryRun {
+					return
+				}
+
 				ctx := context.Background()
 
 				cleanup := setupFileHook(rootOpts.dir)
@@ -138,9 +202,23 @@ func newCreateCmd() *cobra.Command {
 
 	for _, t := range targets {
 		t.command.Args = cobra.ExactArgs(0)
-		t.command.Run = runTargetCmd(t.assets...)
+		if t.command.Run == nil {
+			t.command.Run = runTargetCmd(t.assets...)
+		}
 		cmd.AddCommand(t.command)
 	}
+	for _, in := range input.All {
+		if in.Flag != "" {
+			cmd.PersistentFlags().Var(in, in.Flag, fmt.Sprintf("%s, used instead of prompting with --non-interactive (env %s)", in.Usage, in.Env))
+		}
+	}
+	cmd.PersistentFlags().BoolVar(&createOpts.lenient, "lenient", false, "only warn about fields of install-config.yaml that are not in the install-config schema, instead of failing")
+	cmd.PersistentFlags().BoolVar(&releaseimage.AllowTags, "allow-release-image-tag", false, "allow OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE to reference the release image by tag instead of by digest")
+	cmd.PersistentFlags().StringVar(&createOpts.releaseImageSignatureStore, "release-image-signature-store", "", "URL (http, https or file) of the store with the signatures of the release image, used with --release-image-keyring")
+	cmd.PersistentFlags().StringVar(&createOpts.releaseImageKeyring, "release-image-keyring", "", "GPG keyring with the keys trusted to sign the release image; if set, assets are only created for a release image with a valid signature")
+	installConfigTarget.command.Flags().BoolVar(&installConfigOpts.fromCluster, "from-cluster", false, "reconstruct the install-config of a running cluster, with a placeholder for the pull secret")
+	installConfigTarget.command.Flags().StringVar(&installConfigOpts.kubeconfig, "kubeconfig", "", "kubeconfig of the running cluster for --from-cluster (defaults to $KUBECONFIG or ~/.kube/config)")
+	clusterTarget.command.Flags().BoolVar(&clusterOpts.dryRun, "dry-run", false, "plan the infrastructure with Terraform and print a summary of the resources it would create, without creating anything")
 
 	return cmd
 }
@@ -152,6 +230,14 @@ func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args
 			return errors.Wrap(err, "failed to create asset store")
 		}
 
+		if err := validateInstallConfigFields(directory); err != nil {
+			return err
+		}
+
+		if err := verifyReleaseImage(assetStore); err != nil {
+			return err
+		}
+
 		for _, a := range targets {
 			err := assetStore.Fetch(a)
 			if err != nil {
@@ -185,6 +271,118 @@ func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args
 	}
 }
 
+// validateInstallConfigFields checks that the fields of install-config.yaml
+// are in the install-config schema, unless the install-config was already
+// consumed.
+func validateInstallConfigFields(directory string) error {
+	data, err := ioutil.ReadFile(filepath.Join(directory, "install-config.yaml"))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return installconfig.ValidateFields(data, createOpts.lenient)
+}
+
+// verifyReleaseImage checks the signature of the release image, if a keyring
+// or signature store is set.  The image is verified here instead of when the
+// bootstrap ignition config is generated, because the config may have been
+// generated by an earlier run and loaded from the state file.
+func verifyReleaseImage(assetStore asset.Store) error {
+	if createOpts.releaseImageKeyring == "" && createOpts.releaseImageSignatureStore == "" {
+		return nil
+	}
+	releaseImage := &releaseimage.Image{}
+	if err := assetStore.Peek(releaseImage); err != nil {
+		return errors.Wrapf(err, "failed to fetch %s", releaseImage.Name())
+	}
+	return errors.Wrap(releaseimage.Verify(releaseImage.PullSpec, createOpts.releaseImageKeyring, createOpts.releaseImageSignatureStore), "failed to verify the release image")
+}
+
+// runInstallConfigFromClusterCmd writes the install-config of the cluster
+// that the kubeconfig points at into the directory.
+func runInstallConfigFromClusterCmd(directory string, kubeconfig string) error {
+	path := filepath.Join(directory, "install-config.yaml")
+	if _, err := os.Stat(path); err == nil {
+		return errors.Errorf("%s already exists", path)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+		&clientcmd.ConfigOverrides{},
+	).ClientConfig()
+	if err != nil {
+		return errors.Wrap(err, "loading kubeconfig")
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "creating a Kubernetes client")
+	}
+
+	logrus.Infof("Reading the install-config of the cluster at %s...", config.Host)
+	configMap, err := client.CoreV1().ConfigMaps(installconfig.ClusterConfigNamespace).Get(installconfig.ClusterConfigName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %s/%s", installconfig.ClusterConfigNamespace, installconfig.ClusterConfigName)
+	}
+
+	installConfig, err := installconfig.FromClusterConfigMap(configMap)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(installConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal install-config")
+	}
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+
+	logrus.Infof("Wrote the install-config of cluster %q to %s", installConfig.ObjectMeta.Name, path)
+	logrus.Warnf("Replace the pullSecret placeholder %q with your pull secret, and change the cluster name and base domain if the new cluster must not clash with the existing one", installconfig.PullSecretPlaceholder)
+	return nil
+}
+
+// runPlanCmd plans the cluster infrastructure and prints the resources that
+// Terraform would create, counted by type.
+func runPlanCmd(directory string) error {
+	assetStore, err := assetstore.NewStore(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+
+	if err := validateInstallConfigFields(directory); err != nil {
+		return err
+	}
+
+	// Peek, because a dry run must not consume install-config.yaml or
+	// save the assets it generated along the way to the state file.
+	plan := &cluster.Plan{}
+	if err := assetStore.Peek(plan); err != nil {
+		return errors.Wrapf(err, "failed to fetch %s", plan.Name())
+	}
+
+	resourceTypes := make([]string, 0, len(plan.Resources))
+	total := 0
+	for resourceType, count := range plan.Resources {
+		resourceTypes = append(resourceTypes, resourceType)
+		total += count
+	}
+	sort.Strings(resourceTypes)
+
+	logrus.Infof("Terraform would create %d resources:", total)
+	for _, resourceType := range resourceTypes {
+		logrus.Infof("  %s: %d", resourceType, plan.Resources[resourceType])
+	}
+	return nil
+}
+
 // addRouterCAToClusterCA adds router CA to cluster CA in kubeconfig
 func addRouterCAToClusterCA(config *rest.Config, directory string) (err error) {
 	client, err := kubernetes.NewForConfig(config)
@@ -238,6 +436,17 @@ func addRouterCAToClusterCA(config *rest.Config, directory string) (err error) {
 // FIXME: pulling the kubeconfig and metadata out of the root
 // directory is a bit cludgy when we already have them in memory.
 func waitForBootstrapComplete(ctx context.Context, config *rest.Config, directory string) (err error) {
+	if err := waitForAPI(ctx, config, defaultAPITimeout); err != nil {
+		return err
+	}
+	return waitForBootstrapEvent(ctx, config, defaultBootstrapCompleteTimeout)
+}
+
+// waitForAPI polls the Kubernetes API until it responds or the timeout
+// expires.
+func waitForAPI(ctx context.Context, config *rest.Config, timeout time.Duration) (err error) {
+	defer metrics.Start(metrics.APIWait, "")(&err)
+
 	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return errors.Wrap(err, "creating a Kubernetes client")
@@ -245,9 +454,8 @@ func waitForBootstrapComplete(ctx context.Context, config *rest.Config, director
 
 	discovery := client.Discovery()
 
-	apiTimeout := 30 * time.Minute
-	logrus.Infof("Waiting up to %v for the Kubernetes API at %s...", apiTimeout, config.Host)
-	apiContext, cancel := context.WithTimeout(ctx, apiTimeout)
+	logrus.Infof("Waiting up to %v for the Kubernetes API at %s...", timeout, config.Host)
+	apiContext, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	// Poll quickly so we notice changes, but only log when the response
 	// changes (because that's interesting) or when we've seen 15 of the
@@ -259,6 +467,7 @@ func waitForBootstrapComplete(ctx context.Context, config *rest.Config, director
 		version, err := discovery.ServerVersion()
 		if err == nil {
 			logrus.Infof("API %s up", version)
+			events.Emit(events.APIUp, map[string]string{"host": config.Host, "version": version.String()})
 			cancel()
 		} else {
 			silenceRemaining--
@@ -278,10 +487,26 @@ func waitForBootstrapComplete(ctx context.Context, config *rest.Config, director
 	if err != nil && err != context.Canceled {
 		return errors.Wrap(err, "waiting for Kubernetes API")
 	}
+	return nil
+}
 
-	eventTimeout := 30 * time.Minute
-	logrus.Infof("Waiting up to %v for the bootstrap-complete event...", eventTimeout)
-	return waitForEvent(ctx, client.CoreV1().RESTClient(), "bootstrap-complete", eventTimeout)
+// waitForBootstrapEvent waits for the bootstrap-complete event, which is
+// sent once the bootstrap node has handed the control plane over to the
+// masters.
+func waitForBootstrapEvent(ctx context.Context, config *rest.Config, timeout time.Duration) (err error) {
+	defer metrics.Start(metrics.BootstrapWait, "")(&err)
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "creating a Kubernetes client")
+	}
+
+	logrus.Infof("Waiting up to %v for the bootstrap-complete event...", timeout)
+	if err = waitForEvent(ctx, client.CoreV1().RESTClient(), "bootstrap-complete", timeout); err != nil {
+		return err
+	}
+	events.Emit(events.BootstrapComplete, nil)
+	return nil
 }
 
 // waitForEvent watches the events in the kube-system namespace, waits
@@ -317,8 +542,9 @@ func waitForEvent(ctx context.Context, client cache.Getter, name string, timeout
 
 // waitForInitializedCluster watches the ClusterVersion waiting for confirmation
 // that the cluster has been initialized.
-func waitForInitializedCluster(ctx context.Context, config *rest.Config) error {
-	timeout := 30 * time.Minute
+func waitForInitializedCluster(ctx context.Context, config *rest.Config, timeout time.Duration) (err error) {
+	defer metrics.Start(metrics.ClusterInitWait, "")(&err)
+
 	logrus.Infof("Waiting up to %v for the cluster at %s to initialize...", timeout, config.Host)
 	cc, err := configclient.NewForConfig(config)
 	if err != nil {
@@ -353,8 +579,9 @@ func waitForInitializedCluster(ctx context.Context, config *rest.Config) error {
 					return false, nil
 				}
 				if cov1helpers.IsStatusConditionTrue(cv.Status.Conditions, configv1.OperatorProgressing) {
-					logrus.Debugf("Still waiting for the cluster to initialize: %v",
-						cov1helpers.FindStatusCondition(cv.Status.Conditions, configv1.OperatorProgressing).Message)
+					message := cov1helpers.FindStatusCondition(cv.Status.Conditions, configv1.OperatorProgressing).Message
+					logrus.Debugf("Still waiting for the cluster to initialize: %v", message)
+					events.Emit(events.ClusterOperatorsProgressing, map[string]string{"message": message})
 					return false, nil
 				}
 			}
@@ -374,7 +601,7 @@ func waitForInitializedCluster(ctx context.Context, config *rest.Config) error {
 }
 
 // waitForConsole returns the console URL from the route 'console' in namespace openshift-console
-func waitForConsole(ctx context.Context, config *rest.Config, directory string) (string, error) {
+func waitForConsole(ctx context.Context, config *rest.Config, directory string, timeout time.Duration) (string, error) {
 	url := ""
 	// Need to keep these updated if they change
 	consoleNamespace := "openshift-console"
@@ -384,9 +611,8 @@ func waitForConsole(ctx context.Context, config *rest.Config, directory string)
 		return "", errors.Wrap(err, "creating a route client")
 	}
 
-	consoleRouteTimeout := 10 * time.Minute
-	logrus.Infof("Waiting up to %v for the openshift-console route to be created...", consoleRouteTimeout)
-	consoleRouteContext, cancel := context.WithTimeout(ctx, consoleRouteTimeout)
+	logrus.Infof("Waiting up to %v for the openshift-console route to be created...", timeout)
+	consoleRouteContext, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	// Poll quickly but only log when the response
 	// when we've seen 15 of the same errors or output of
@@ -440,20 +666,22 @@ func logComplete(directory, consoleURL string) error {
 	if err != nil {
 		return err
 	}
+	redact.AddSecret(string(pw))
 	logrus.Info("Install complete!")
 	logrus.Infof("Run 'export KUBECONFIG=%s' to manage the cluster with 'oc', the OpenShift CLI.", kubeconfig)
 	logrus.Infof("The cluster is ready when 'oc login -u kubeadmin -p %s' succeeds (wait a few minutes).", pw)
 	logrus.Infof("Access the OpenShift web-console here: %s", consoleURL)
 	logrus.Infof("Login to the console with user: kubeadmin, password: %s", pw)
+	events.Emit(events.InstallComplete, map[string]string{"console_url": consoleURL, "kubeconfig": kubeconfig})
 	return nil
 }
 
 func finish(ctx context.Context, config *rest.Config, directory string) error {
-	if err := waitForInitializedCluster(ctx, config); err != nil {
+	if err := waitForInitializedCluster(ctx, config, defaultInstallCompleteTimeout); err != nil {
 		return err
 	}
 
-	consoleURL, err := waitForConsole(ctx, config, rootOpts.dir)
+	consoleURL, err := waitForConsole(ctx, config, rootOpts.dir, defaultConsoleTimeout)
 	if err != nil {
 		return err
 	}
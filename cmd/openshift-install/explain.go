@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/explain"
+)
+
+var (
+	explainLong = `Describe the fields of the install-config.
+
+The field is identified by a dot-separated path starting at
+'installconfig', using the names from install-config.yaml.  For
+example:
+
+  openshift-install explain installconfig
+  openshift-install explain installconfig.networking
+  openshift-install explain installconfig.platform.aws.defaultMachinePlatform
+
+Each field is listed with its type, whether it is required, and its
+default value when it has one.`
+)
+
+func newExplainCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain PATH",
+		Short: "Describe the fields of the install-config",
+		Long:  explainLong,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return explain.Explain(os.Stdout, args[0])
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/asset"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/asset/tls"
+	gatherbootstrap "github.com/openshift/installer/pkg/gather/bootstrap"
+)
+
+var (
+	gatherBootstrapOpts struct {
+		bootstrap string
+	}
+)
+
+func newGatherCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gather",
+		Short: "Gather debugging data for a given installation failure",
+		Long: `Gather debugging data for a given installation failure.
+
+When installation for OpenShift cluster fails, gathering all the data useful for debugging can
+become a difficult task. This command helps users to collect the most relevant information that can be used
+to debug the installation failures`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newGatherBootstrapCmd())
+	return cmd
+}
+
+func newGatherBootstrapCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Gather the journals of the bootstrap node",
+		Args:  cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			err := runGatherBootstrapCmd(rootOpts.dir)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+		},
+	}
+	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.bootstrap, "bootstrap", "", "Hostname or IP of the bootstrap host (defaults to the address in the Terraform state)")
+	return cmd
+}
+
+func runGatherBootstrapCmd(directory string) error {
+	host := gatherBootstrapOpts.bootstrap
+	if host == "" {
+		var err error
+		host, err = gatherbootstrap.Host(directory)
+		if err != nil {
+			return errors.Wrap(err, "failed to find the bootstrap host, use --bootstrap to set it")
+		}
+	}
+
+	assetStore, err := assetstore.NewStore(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+
+	// Load, because certificates generated now would not be trusted by
+	// the bootstrap node.
+	journalCertKey := &tls.JournalCertKey{}
+	rootCA := &tls.RootCA{}
+	for _, a := range []asset.Asset{journalCertKey, rootCA} {
+		found, err := assetStore.Load(a)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load %s", a.Name())
+		}
+		if !found {
+			return errors.Errorf("no install state found in --dir %q: %s is missing", directory, a.Name())
+		}
+	}
+
+	tarball := filepath.Join(directory, fmt.Sprintf("log-bundle-%s.tar.gz", time.Now().Format("20060102150405")))
+	if err := gatherbootstrap.Gather(host, journalCertKey.Cert(), journalCertKey.Key(), rootCA.Cert(), tarball); err != nil {
+		return errors.Wrap(err, "failed to gather bootstrap journals")
+	}
+
+	logrus.Infof("Bootstrap gather logs captured here %q", tarball)
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/installer/pkg/redact"
 	"github.com/openshift/installer/pkg/version"
 )
 
@@ -46,6 +47,27 @@ func (h *fileHook) Fire(entry *logrus.Entry) error {
 	return err
 }
 
+// redactingFormatter masks secrets in log entries before formatting them.
+type redactingFormatter struct {
+	logrus.Formatter
+}
+
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	redacted := *entry
+	redacted.Message = redact.String(entry.Message)
+	redacted.Data = make(logrus.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		switch v := value.(type) {
+		case string:
+			value = redact.String(v)
+		case error:
+			value = redact.String(v.Error())
+		}
+		redacted.Data[key] = value
+	}
+	return f.Formatter.Format(&redacted)
+}
+
 func setupFileHook(baseDir string) func() {
 	if err := os.MkdirAll(baseDir, 0755); err != nil {
 		logrus.Fatal(errors.Wrap(err, "failed to create base directory for logs"))
@@ -60,14 +82,18 @@ func setupFileHook(baseDir string) func() {
 	for k, v := range logrus.StandardLogger().Hooks {
 		originalHooks[k] = v
 	}
-	logrus.AddHook(newFileHook(logfile, logrus.TraceLevel, &logrus.TextFormatter{
-		DisableColors:          true,
-		DisableTimestamp:       false,
-		FullTimestamp:          true,
-		DisableLevelTruncation: false,
+	// The log file is often attached to bug reports, so keep secrets out
+	// of it.
+	logrus.AddHook(newFileHook(logfile, logrus.TraceLevel, &redactingFormatter{
+		Formatter: &logrus.TextFormatter{
+			DisableColors:          true,
+			DisableTimestamp:       false,
+			FullTimestamp:          true,
+			DisableLevelTruncation: false,
+		},
 	}))
 
-	logrus.Debugf(version.String)
+	logrus.Debug(version.String)
 	if version.Commit != "" {
 		logrus.Debugf("Built from commit %s", version.Commit)
 	}
@@ -12,13 +12,19 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
+	"github.com/openshift/installer/pkg/events"
 	"github.com/openshift/installer/pkg/terraform/exec/plugins"
 )
 
 var (
 	rootOpts struct {
-		dir      string
-		logLevel string
+		dir             string
+		logLevel        string
+		logFormat       string
+		eventsFile      string
+		metricsTextfile string
+		nonInteractive  bool
 	}
 )
 
@@ -46,9 +52,15 @@ func installerMain() {
 	for _, subCmd := range []*cobra.Command{
 		newCreateCmd(),
 		newDestroyCmd(),
+		newWaitForCmd(),
+		newGatherCmd(),
 		newUPICmd(),
 		newVersionCmd(),
 		newGraphCmd(),
+		newExplainCmd(),
+		newSchemaCmd(),
+		newValidateCmd(),
+		newMigrateCmd(),
 		newCompletionCmd(),
 	} {
 		rootCmd.AddCommand(subCmd)
@@ -65,11 +77,18 @@ func newRootCmd() *cobra.Command {
 		Short:            "Creates OpenShift clusters",
 		Long:             "",
 		PersistentPreRun: runRootCmd,
-		SilenceErrors:    true,
-		SilenceUsage:     true,
+		PersistentPostRun: func(cmd *cobra.Command, _ []string) {
+			writeMetrics(cmd)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
 	cmd.PersistentFlags().StringVar(&rootOpts.dir, "dir", ".", "assets directory")
 	cmd.PersistentFlags().StringVar(&rootOpts.logLevel, "log-level", "info", "log level (e.g. \"debug | info | warn | error\")")
+	cmd.PersistentFlags().StringVar(&rootOpts.logFormat, "log-format", "text", "log format (e.g. \"text | json\")")
+	cmd.PersistentFlags().StringVar(&rootOpts.eventsFile, "events-file", "", "append machine-readable progress events to this file, one JSON object per line")
+	cmd.PersistentFlags().StringVar(&rootOpts.metricsTextfile, "metrics-textfile", "", "also write the timings of the installation phases to this file, in Prometheus textfile collector format")
+	cmd.PersistentFlags().BoolVar(&rootOpts.nonInteractive, "non-interactive", false, "never prompt; take inputs from flags and environment variables, and fail listing the missing ones")
 	return cmd
 }
 
@@ -82,18 +101,49 @@ func runRootCmd(cmd *cobra.Command, args []string) {
 		level = logrus.InfoLevel
 	}
 
-	logrus.AddHook(newFileHook(os.Stderr, level, &logrus.TextFormatter{
-		// Setting ForceColors is necessary because logrus.TextFormatter determines
-		// whether or not to enable colors by looking at the output of the logger.
-		// In this case, the output is ioutil.Discard, which is not a terminal.
-		// Overriding it here allows the same check to be done, but against the
-		// hook's output instead of the logger's output.
-		ForceColors:            terminal.IsTerminal(int(os.Stderr.Fd())),
-		DisableTimestamp:       true,
-		DisableLevelTruncation: true,
-	}))
+	var formatter logrus.Formatter
+	var formatErr error
+	switch rootOpts.logFormat {
+	case "json":
+		formatter = &logrus.JSONFormatter{}
+	default:
+		if rootOpts.logFormat != "text" {
+			formatErr = errors.Errorf("unrecognized log format %q", rootOpts.logFormat)
+		}
+		formatter = &logrus.TextFormatter{
+			// Setting ForceColors is necessary because logrus.TextFormatter determines
+			// whether or not to enable colors by looking at the output of the logger.
+			// In this case, the output is ioutil.Discard, which is not a terminal.
+			// Overriding it here allows the same check to be done, but against the
+			// hook's output instead of the logger's output.
+			ForceColors:            terminal.IsTerminal(int(os.Stderr.Fd())),
+			DisableTimestamp:       true,
+			DisableLevelTruncation: true,
+		}
+	}
+
+	logrus.AddHook(newFileHook(os.Stderr, level, formatter))
 
 	if err != nil {
 		logrus.Fatal(errors.Wrap(err, "invalid log-level"))
 	}
+	if formatErr != nil {
+		logrus.Fatal(errors.Wrap(formatErr, "invalid log-format"))
+	}
+
+	if rootOpts.eventsFile != "" {
+		eventsFile, err := os.OpenFile(rootOpts.eventsFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+		if err != nil {
+			logrus.Fatal(errors.Wrap(err, "failed to open events file"))
+		}
+		events.SetOutput(eventsFile)
+	}
+
+	input.NonInteractive = rootOpts.nonInteractive
+
+	// Write the timings even if the command fails, as failed installations
+	// are as interesting as successful ones.
+	logrus.RegisterExitHandler(func() {
+		writeMetrics(cmd)
+	})
 }
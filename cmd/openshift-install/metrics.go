@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/metrics"
+	"github.com/openshift/installer/pkg/version"
+)
+
+// writeMetrics writes the timings recorded while running the command into
+// the asset directory and, if requested, into a Prometheus textfile.  It is
+// called on exit whether or not the command succeeded, so failures are
+// only logged.
+func writeMetrics(cmd *cobra.Command) {
+	if len(metrics.Timings()) == 0 {
+		return
+	}
+
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if err := metrics.WriteReport(rootOpts.dir, version.Raw, command); err != nil {
+		logrus.Warn(errors.Wrap(err, "failed to write the metrics report"))
+	}
+
+	if rootOpts.metricsTextfile != "" {
+		if err := metrics.WriteTextfile(rootOpts.metricsTextfile, version.Raw); err != nil {
+			logrus.Warn(errors.Wrap(err, "failed to write the metrics textfile"))
+		}
+	}
+}
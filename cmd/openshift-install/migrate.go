@@ -0,0 +1,144 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/conversion"
+)
+
+var (
+	migrateInstallConfigOpts struct {
+		output      string
+		dropUnknown bool
+	}
+
+	migrateInstallConfigLong = `Rewrite install-config.yaml in the asset directory at the current
+install-config version (` + types.InstallConfigVersion + `).
+
+Every field that is moved or dropped is listed.  Fields that were
+deprecated are moved to their replacements, and fields that are
+overridden by their replacements are dropped.  Fields that are unknown to
+the current version are only dropped with --drop-unknown-fields.
+Comments and the order of the fields are not preserved.
+
+Unless --output is set, the original file is kept as install-config.yaml.bak
+before install-config.yaml is rewritten.`
+)
+
+func newMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate input files to the current version",
+		Long:  "",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newMigrateInstallConfigCmd())
+	return cmd
+}
+
+func newMigrateInstallConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install-config",
+		Short: "Rewrite install-config.yaml at the current version",
+		Long:  migrateInstallConfigLong,
+		Args:  cobra.ExactArgs(0),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return migrateInstallConfig(filepath.Join(rootOpts.dir, "install-config.yaml"), migrateInstallConfigOpts.output, migrateInstallConfigOpts.dropUnknown)
+		},
+	}
+	cmd.Flags().StringVar(&migrateInstallConfigOpts.output, "output", "", "write the migrated install-config to this file instead of rewriting install-config.yaml")
+	cmd.Flags().BoolVar(&migrateInstallConfigOpts.dropUnknown, "drop-unknown-fields", false, "drop the fields that are unknown to the current version instead of failing")
+	return cmd
+}
+
+// migrateInstallConfig migrates the install-config at path and writes it to
+// output, or back to path after keeping the original as path.bak.  It fails
+// without writing anything if fields would be dropped because they are
+// unknown, unless dropUnknown is true.
+func migrateInstallConfig(path string, output string, dropUnknown bool) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	original := &metav1.TypeMeta{}
+	if err := yaml.Unmarshal(data, original); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal %s", path)
+	}
+	config, changes, err := conversion.MigrateYAML(data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to migrate %s", path)
+	}
+	var unknown []string
+	for _, change := range changes {
+		if change.Unknown {
+			unknown = append(unknown, change.Field)
+		}
+	}
+	if len(unknown) > 0 && !dropUnknown {
+		return errors.Errorf("%s has fields that are unknown to version %s: %s; fix them, or pass --drop-unknown-fields to drop them", path, types.InstallConfigVersion, strings.Join(unknown, ", "))
+	}
+	for _, change := range changes {
+		if change.MovedTo == "" {
+			logrus.Warn(change)
+		} else {
+			logrus.Info(change)
+		}
+	}
+
+	if len(changes) == 0 && original.APIVersion == config.APIVersion && output == "" {
+		logrus.Infof("%s is already at version %s", path, config.APIVersion)
+		return nil
+	}
+	migrated, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the migrated install-config")
+	}
+
+	if output == "" {
+		output = path
+		backup := path + ".bak"
+		if err := writeNewFile(backup, data, info.Mode()); err != nil {
+			return errors.Wrap(err, "failed to keep the original install-config")
+		}
+		logrus.Infof("Kept the original install-config as %s", backup)
+	}
+	if err := ioutil.WriteFile(output, migrated, info.Mode()); err != nil {
+		return err
+	}
+	if output == path {
+		logrus.Infof("Migrated %s from version %s to %s", path, original.APIVersion, config.APIVersion)
+	} else {
+		logrus.Infof("Migrated %s from version %s to %s into %s", path, original.APIVersion, config.APIVersion, output)
+	}
+	return nil
+}
+
+// writeNewFile writes data to a file that must not exist yet.
+func writeNewFile(path string, data []byte, mode os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
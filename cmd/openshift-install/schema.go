@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/explain"
+)
+
+var (
+	schemaLong = `Print the JSON Schema of install-config.yaml.
+
+The schema is generated from the same types the installer loads the
+install-config into, so editors and linters can use it to check an
+install-config.yaml before the installer is run.  For example:
+
+  openshift-install schema > install-config.schema.json
+
+The installer checks install-config.yaml against the schema when it is
+loaded, before the more detailed validation of the values.`
+)
+
+func newSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the install-config",
+		Long:  schemaLong,
+		Args:  cobra.ExactArgs(0),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			schema, err := explain.InstallConfigSchema()
+			if err != nil {
+				return errors.Wrap(err, "failed to generate the install-config schema")
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(schema)
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/preflight"
+)
+
+var (
+	validateOpts struct {
+		online  bool
+		lenient bool
+	}
+
+	validateLong = `Check install-config.yaml in the asset directory without creating
+anything, and print a table of the checks with their status.
+
+Offline, the install-config is checked against its schema and validated
+as it is when a cluster is created, and values that are likely to be
+mistakes, such as an even number of control plane replicas or host
+prefixes too small for the pods of a node, are reported as warnings.
+
+With --online, the platform is also checked against the cloud, using the
+same credentials as "create cluster".  On AWS, the availability zones of
+the region, the Route53 zone of the base domain and any existing subnets
+are checked, and instance types without reserved instance offerings in a
+zone are reported as warnings.  On OpenStack, the cloud, region, external
+network and flavor are checked.
+
+The command fails only if a check fails; warnings do not change the exit
+status.`
+)
+
+func newValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the install-config without creating a cluster",
+		Long:  validateLong,
+		Args:  cobra.ExactArgs(0),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			path := filepath.Join(rootOpts.dir, "install-config.yaml")
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			results := preflight.Validate("install-config.yaml", data, validateOpts.online, validateOpts.lenient)
+			if err := printResults(results); err != nil {
+				return err
+			}
+			if preflight.Failed(results) {
+				return errors.Errorf("%s failed validation", path)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&validateOpts.online, "online", false, "also check the platform against the cloud")
+	cmd.Flags().BoolVar(&validateOpts.lenient, "lenient", false, "warn about fields that are not in the install-config schema, instead of failing")
+	return cmd
+}
+
+// printResults prints a row for each check, followed by a row for each
+// additional message of the check.
+func printResults(results []preflight.Result) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tSTATUS\tDETAILS")
+	for _, r := range results {
+		var messages []string
+		for _, message := range r.Messages {
+			messages = append(messages, strings.Split(message, "\n")...)
+		}
+		if len(messages) == 0 {
+			messages = []string{""}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Check, r.Status, messages[0])
+		for _, message := range messages[1:] {
+			fmt.Fprintf(tw, "\t\t%s\n", message)
+		}
+	}
+	return tw.Flush()
+}
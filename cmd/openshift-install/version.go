@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
 	"github.com/openshift/installer/pkg/version"
 )
 
@@ -13,7 +16,7 @@ func newVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
 		Short: "Print version information",
-		Long:  "",
+		Long:  "Print version information, including the release image used for the install directory (or the release image that would be used when the directory has none).",
 		Args:  cobra.ExactArgs(0),
 		RunE:  runVersionCmd,
 	}
@@ -24,5 +27,23 @@ func runVersionCmd(cmd *cobra.Command, args []string) error {
 	if version.Commit != "" {
 		fmt.Printf("built from commit %s\n", version.Commit)
 	}
+
+	assetStore, err := assetstore.NewStore(rootOpts.dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+	releaseImage := &releaseimage.Image{}
+	found, err := assetStore.Load(releaseImage)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the release image")
+	}
+	switch {
+	case found:
+		fmt.Printf("release image %s\n", releaseImage.PullSpec)
+	case releaseimage.Override() != "":
+		fmt.Printf("release image %s (OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE)\n", releaseimage.Override())
+	default:
+		fmt.Printf("release image %s (default)\n", releaseimage.Default())
+	}
 	return nil
 }
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Exit codes for the wait-for subcommands, so that callers can tell which
+// stage failed and retry only that stage.
+const (
+	exitCodeAPI               = 3
+	exitCodeBootstrapComplete = 4
+	exitCodeInstallComplete   = 5
+	exitCodeConsole           = 6
+)
+
+var (
+	waitForOpts struct {
+		apiTimeout               time.Duration
+		bootstrapCompleteTimeout time.Duration
+		installCompleteTimeout   time.Duration
+		consoleTimeout           time.Duration
+	}
+
+	waitForLong = `Wait for individual stages of the cluster installation.
+
+Each stage can be waited on separately and has its own timeout, so a
+stage that failed because of a transient problem can be retried without
+re-running the whole installation.  On failure, each stage exits with its
+own code:
+
+  api                 3
+  bootstrap-complete  4
+  install-complete    5
+  console             6`
+)
+
+func newWaitForCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait-for",
+		Short: "Wait for install-time events",
+		Long:  waitForLong,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newWaitForAPICmd())
+	cmd.AddCommand(newWaitForBootstrapCompleteCmd())
+	cmd.AddCommand(newWaitForInstallCompleteCmd())
+	cmd.AddCommand(newWaitForConsoleCmd())
+	return cmd
+}
+
+// The timeout flags are only registered on the subcommands that wait for
+// the corresponding stage.
+
+func addAPITimeoutFlag(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&waitForOpts.apiTimeout, "api-timeout", defaultAPITimeout, "how long to wait for the Kubernetes API")
+}
+
+func addBootstrapCompleteTimeoutFlag(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&waitForOpts.bootstrapCompleteTimeout, "bootstrap-complete-timeout", defaultBootstrapCompleteTimeout, "how long to wait for the bootstrap-complete event")
+}
+
+func addInstallCompleteTimeoutFlag(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&waitForOpts.installCompleteTimeout, "install-complete-timeout", defaultInstallCompleteTimeout, "how long to wait for the cluster to initialize")
+}
+
+func addConsoleTimeoutFlag(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&waitForOpts.consoleTimeout, "console-timeout", defaultConsoleTimeout, "how long to wait for the openshift-console route")
+}
+
+func newWaitForAPICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Wait until the Kubernetes API is up",
+		Args:  cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := context.Background()
+
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			config := loadAdminKubeconfig(rootOpts.dir)
+
+			err := waitForAPI(ctx, config, waitForOpts.apiTimeout)
+			if err != nil {
+				exitWithCode(err, exitCodeAPI)
+			}
+		},
+	}
+	addAPITimeoutFlag(cmd)
+	return cmd
+}
+
+func newWaitForBootstrapCompleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bootstrap-complete",
+		Short: "Wait until cluster bootstrapping has completed",
+		Args:  cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := context.Background()
+
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			config := loadAdminKubeconfig(rootOpts.dir)
+
+			err := waitForAPI(ctx, config, waitForOpts.apiTimeout)
+			if err != nil {
+				exitWithCode(err, exitCodeAPI)
+			}
+
+			err = waitForBootstrapEvent(ctx, config, waitForOpts.bootstrapCompleteTimeout)
+			if err != nil {
+				exitWithCode(err, exitCodeBootstrapComplete)
+			}
+
+			logrus.Info("It is now safe to remove the bootstrap resources")
+		},
+	}
+	addAPITimeoutFlag(cmd)
+	addBootstrapCompleteTimeoutFlag(cmd)
+	return cmd
+}
+
+func newWaitForInstallCompleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install-complete",
+		Short: "Wait until the cluster is ready",
+		Args:  cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := context.Background()
+
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			config := loadAdminKubeconfig(rootOpts.dir)
+
+			err := waitForInitializedCluster(ctx, config, waitForOpts.installCompleteTimeout)
+			if err != nil {
+				exitWithCode(err, exitCodeInstallComplete)
+			}
+
+			consoleURL, err := waitForConsole(ctx, config, rootOpts.dir, waitForOpts.consoleTimeout)
+			if err != nil {
+				exitWithCode(err, exitCodeConsole)
+			}
+
+			if err = addRouterCAToClusterCA(config, rootOpts.dir); err != nil {
+				exitWithCode(err, exitCodeInstallComplete)
+			}
+
+			if err = logComplete(rootOpts.dir, consoleURL); err != nil {
+				exitWithCode(err, exitCodeInstallComplete)
+			}
+		},
+	}
+	addInstallCompleteTimeoutFlag(cmd)
+	addConsoleTimeoutFlag(cmd)
+	return cmd
+}
+
+func newWaitForConsoleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "console",
+		Short: "Wait until the openshift-console route is created",
+		Args:  cobra.ExactArgs(0),
+		Run: func(_ *cobra.Command, _ []string) {
+			ctx := context.Background()
+
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+
+			config := loadAdminKubeconfig(rootOpts.dir)
+
+			consoleURL, err := waitForConsole(ctx, config, rootOpts.dir, waitForOpts.consoleTimeout)
+			if err != nil {
+				exitWithCode(err, exitCodeConsole)
+			}
+
+			logrus.Infof("Access the OpenShift web-console here: %s", consoleURL)
+		},
+	}
+	addConsoleTimeoutFlag(cmd)
+	return cmd
+}
+
+// loadAdminKubeconfig loads the admin kubeconfig from the asset directory.
+func loadAdminKubeconfig(directory string) *rest.Config {
+	config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(directory, "auth", "kubeconfig"))
+	if err != nil {
+		logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
+	}
+	return config
+}
+
+// exitWithCode logs the error and exits with the given stage-specific code.
+func exitWithCode(err error, code int) {
+	logrus.Error(err)
+	logrus.Exit(code)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForTimeouts(t *testing.T) {
+	cases := []struct {
+		name          string
+		subcommand    string
+		args          []string
+		expected      map[string]time.Duration
+		expectedError string
+	}{
+		{
+			name:       "api defaults",
+			subcommand: "api",
+			expected: map[string]time.Duration{
+				"api-timeout": defaultAPITimeout,
+			},
+		},
+		{
+			name:       "api timeout",
+			subcommand: "api",
+			args:       []string{"--api-timeout=45m"},
+			expected: map[string]time.Duration{
+				"api-timeout": 45 * time.Minute,
+			},
+		},
+		{
+			name:       "bootstrap-complete defaults",
+			subcommand: "bootstrap-complete",
+			expected: map[string]time.Duration{
+				"api-timeout":                defaultAPITimeout,
+				"bootstrap-complete-timeout": defaultBootstrapCompleteTimeout,
+			},
+		},
+		{
+			name:       "bootstrap-complete timeouts",
+			subcommand: "bootstrap-complete",
+			args:       []string{"--api-timeout=5m", "--bootstrap-complete-timeout", "1h"},
+			expected: map[string]time.Duration{
+				"api-timeout":                5 * time.Minute,
+				"bootstrap-complete-timeout": time.Hour,
+			},
+		},
+		{
+			name:       "install-complete defaults",
+			subcommand: "install-complete",
+			expected: map[string]time.Duration{
+				"install-complete-timeout": defaultInstallCompleteTimeout,
+				"console-timeout":          defaultConsoleTimeout,
+			},
+		},
+		{
+			name:       "console timeout",
+			subcommand: "console",
+			args:       []string{"--console-timeout=90s"},
+			expected: map[string]time.Duration{
+				"console-timeout": 90 * time.Second,
+			},
+		},
+		{
+			name:          "invalid duration",
+			subcommand:    "api",
+			args:          []string{"--api-timeout=30"},
+			expectedError: `invalid argument "30" for "--api-timeout" flag: time: missing unit in duration "?30"?`,
+		},
+		{
+			name:          "timeout of another subcommand",
+			subcommand:    "api",
+			args:          []string{"--console-timeout=5m"},
+			expectedError: `^unknown flag: --console-timeout$`,
+		},
+		{
+			name:          "timeout of an earlier stage",
+			subcommand:    "console",
+			args:          []string{"--install-complete-timeout=5m"},
+			expectedError: `^unknown flag: --install-complete-timeout$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := waitForOpts
+			defer func() { waitForOpts = opts }()
+
+			cmd, _, err := newWaitForCmd().Find([]string{tc.subcommand})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = cmd.ParseFlags(tc.args)
+			if tc.expectedError != "" {
+				assert.Regexp(t, tc.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			timeouts := map[string]time.Duration{}
+			cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				if strings.HasSuffix(flag.Name, "-timeout") {
+					timeouts[flag.Name], err = cmd.Flags().GetDuration(flag.Name)
+					assert.NoError(t, err)
+				}
+			})
+			assert.Equal(t, tc.expected, timeouts)
+		})
+	}
+}
@@ -62,3 +62,21 @@ variable "aws_master_availability_zones" {
   type        = "list"
   description = "The availability zones in which to create the masters. The length of this list must match master_count."
 }
+
+variable "aws_vpc" {
+  type        = "string"
+  default     = ""
+  description = "(optional) An existing VPC to install the cluster into, instead of creating a new one. Example: `vpc-123456`."
+}
+
+variable "aws_public_subnets" {
+  type        = "list"
+  default     = []
+  description = "(optional) Existing public subnets, in aws_vpc, for the bootstrap node and the external load balancer."
+}
+
+variable "aws_private_subnets" {
+  type        = "list"
+  default     = []
+  description = "(optional) Existing private subnets, in aws_vpc, for the master nodes and the internal load balancer."
+}
@@ -12,17 +12,35 @@ locals {
   // List of possible AZs for each type of subnet
   new_subnet_azs = "${data.aws_availability_zones.azs.names}"
 
+  // Whether to create a new VPC, or install into an existing one
+  new_vpc_count = "${var.vpc == "" ? 1 : 0}"
+
   // How many AZs to create subnets in
-  new_az_count = "${length(local.new_subnet_azs)}"
+  new_az_count = "${var.vpc == "" ? length(local.new_subnet_azs) : 0}"
 
   // The VPC ID to use to build the rest of the vpc data sources
-  vpc_id = "${aws_vpc.new_vpc.id}"
+  vpc_id = "${var.vpc == "" ? join("", aws_vpc.new_vpc.*.id) : var.vpc}"
 
   // When referencing the _ids arrays or data source arrays via count = , always use the *_count variable rather than taking the length of the list
-  private_subnet_ids   = "${aws_subnet.private_subnet.*.id}"
-  public_subnet_ids    = "${aws_subnet.public_subnet.*.id}"
-  private_subnet_count = "${local.new_az_count}"
-  public_subnet_count  = "${local.new_az_count}"
+  private_subnet_ids   = "${split(",", var.vpc == "" ? join(",", aws_subnet.private_subnet.*.id) : join(",", var.private_subnets))}"
+  public_subnet_ids    = "${split(",", var.vpc == "" ? join(",", aws_subnet.public_subnet.*.id) : join(",", var.public_subnets))}"
+  private_subnet_count = "${var.vpc == "" ? local.new_az_count : length(var.private_subnets)}"
+  public_subnet_count  = "${var.vpc == "" ? local.new_az_count : length(var.public_subnets)}"
+
+  // The AZs of the subnets, in the same order as the _ids arrays
+  private_subnet_azs = "${split(",", var.vpc == "" ? join(",", local.new_subnet_azs) : join(",", data.aws_subnet.private.*.availability_zone))}"
+  public_subnet_azs  = "${split(",", var.vpc == "" ? join(",", local.new_subnet_azs) : join(",", data.aws_subnet.public.*.availability_zone))}"
+}
+
+// The existing subnets, if any
+data "aws_subnet" "private" {
+  count = "${var.vpc == "" ? 0 : length(var.private_subnets)}"
+  id    = "${var.private_subnets[count.index]}"
+}
+
+data "aws_subnet" "public" {
+  count = "${var.vpc == "" ? 0 : length(var.public_subnets)}"
+  id    = "${var.public_subnets[count.index]}"
 }
 
 # all data sources should be input variable-agnostic and used as canonical source for querying "state of resources" and building outputs
@@ -16,6 +16,18 @@ variable "public_master_endpoints" {
   default     = true
 }
 
+variable "private_subnets" {
+  type        = "list"
+  default     = []
+  description = "(optional) Existing private subnets, in the existing VPC, to install the cluster into."
+}
+
+variable "public_subnets" {
+  type        = "list"
+  default     = []
+  description = "(optional) Existing public subnets, in the existing VPC, to install the cluster into."
+}
+
 variable "region" {
   type        = "string"
   description = "The target AWS region for the cluster."
@@ -26,3 +38,9 @@ variable "tags" {
   default     = {}
   description = "AWS tags to be applied to created resources."
 }
+
+variable "vpc" {
+  type        = "string"
+  default     = ""
+  description = "(optional) An existing VPC to install the cluster into, instead of creating a new one."
+}
@@ -1,4 +1,5 @@
 resource "aws_internet_gateway" "igw" {
+  count  = "${local.new_vpc_count}"
   vpc_id = "${data.aws_vpc.cluster_vpc.id}"
 
   tags = "${merge(map(
@@ -7,6 +8,7 @@ resource "aws_internet_gateway" "igw" {
 }
 
 resource "aws_route_table" "default" {
+  count  = "${local.new_vpc_count}"
   vpc_id = "${data.aws_vpc.cluster_vpc.id}"
 
   tags = "${merge(map(
@@ -15,14 +17,16 @@ resource "aws_route_table" "default" {
 }
 
 resource "aws_main_route_table_association" "main_vpc_routes" {
+  count          = "${local.new_vpc_count}"
   vpc_id         = "${data.aws_vpc.cluster_vpc.id}"
-  route_table_id = "${aws_route_table.default.id}"
+  route_table_id = "${join("", aws_route_table.default.*.id)}"
 }
 
 resource "aws_route" "igw_route" {
+  count                  = "${local.new_vpc_count}"
   destination_cidr_block = "0.0.0.0/0"
-  route_table_id         = "${aws_route_table.default.id}"
-  gateway_id             = "${aws_internet_gateway.igw.id}"
+  route_table_id         = "${join("", aws_route_table.default.*.id)}"
+  gateway_id             = "${join("", aws_internet_gateway.igw.*.id)}"
 }
 
 resource "aws_subnet" "public_subnet" {
@@ -40,7 +44,7 @@ resource "aws_subnet" "public_subnet" {
 
 resource "aws_route_table_association" "route_net" {
   count          = "${local.new_az_count}"
-  route_table_id = "${aws_route_table.default.id}"
+  route_table_id = "${join("", aws_route_table.default.*.id)}"
   subnet_id      = "${aws_subnet.public_subnet.*.id[count.index]}"
 }
 
@@ -4,6 +4,7 @@ locals {
 }
 
 resource "aws_vpc" "new_vpc" {
+  count                = "${local.new_vpc_count}"
   cidr_block           = "${var.cidr_block}"
   enable_dns_hostnames = true
   enable_dns_support   = true
@@ -14,12 +15,14 @@ resource "aws_vpc" "new_vpc" {
 }
 
 resource "aws_vpc_endpoint" "s3" {
-  vpc_id          = "${aws_vpc.new_vpc.id}"
+  count           = "${local.new_vpc_count}"
+  vpc_id          = "${local.vpc_id}"
   service_name    = "com.amazonaws.${var.region}.s3"
   route_table_ids = ["${concat(aws_route_table.private_routes.*.id, aws_route_table.default.*.id)}"]
 }
 
 resource "aws_vpc_dhcp_options" "main" {
+  count               = "${local.new_vpc_count}"
   domain_name         = "${var.region == "us-east-1" ? "ec2.internal" : format("%s.compute.internal", var.region)}"
   domain_name_servers = ["AmazonProvidedDNS"]
 
@@ -27,6 +30,7 @@ resource "aws_vpc_dhcp_options" "main" {
 }
 
 resource "aws_vpc_dhcp_options_association" "main" {
-  vpc_id          = "${aws_vpc.new_vpc.id}"
-  dhcp_options_id = "${aws_vpc_dhcp_options.main.id}"
+  count           = "${local.new_vpc_count}"
+  vpc_id          = "${local.vpc_id}"
+  dhcp_options_id = "${join("", aws_vpc_dhcp_options.main.*.id)}"
 }
@@ -44,7 +44,14 @@ release)
 	TAGS="${TAGS} release"
 	if test -n "${RELEASE_IMAGE}"
 	then
-		LDFLAGS="${LDFLAGS} -X github.com/openshift/installer/pkg/asset/ignition/bootstrap.defaultReleaseImage=${RELEASE_IMAGE}"
+		case "${RELEASE_IMAGE}" in
+		*@sha256:*)
+			;;
+		*)
+			echo "RELEASE_IMAGE must reference the release image by digest, like <repository>@sha256:<digest>: ${RELEASE_IMAGE}" >&2
+			exit 1
+		esac
+		LDFLAGS="${LDFLAGS} -X github.com/openshift/installer/pkg/asset/releaseimage.defaultImage=${RELEASE_IMAGE}"
 	fi
 	if test -n "${RHCOS_BUILD_NAME}"
 	then
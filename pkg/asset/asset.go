@@ -36,6 +36,16 @@ type WritableAsset interface {
 	Load(FileFetcher) (found bool, err error)
 }
 
+// SecretAsset is an Asset that holds secrets.  The store registers them
+// with the redact package whenever the asset is generated or loaded, so
+// that they are masked in logs whichever way the asset was fetched.
+type SecretAsset interface {
+	Asset
+
+	// Secrets returns the secret values held by the asset.
+	Secrets() []string
+}
+
 // File is a file for an Asset.
 type File struct {
 	// Filename is the name of the file.
@@ -4,6 +4,11 @@ package aws
 import (
 	"fmt"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
 )
@@ -19,3 +24,26 @@ func Metadata(clusterID, infraID string, config *types.InstallConfig) *aws.Metad
 		}},
 	}
 }
+
+// TagSharedSubnets tags the existing subnets of the install configuration
+// as shared with the cluster, so that the cluster creates its load balancers
+// in them.  Destroying the cluster removes the tags, but not the subnets.
+func TagSharedSubnets(infraID string, config *types.InstallConfig) error {
+	ids := config.Platform.AWS.Subnets
+	if len(ids) == 0 {
+		return nil
+	}
+	ssn, err := awsconfig.GetSession()
+	if err != nil {
+		return err
+	}
+	client := ec2.New(ssn, awssdk.NewConfig().WithRegion(config.Platform.AWS.Region))
+	_, err = client.CreateTags(&ec2.CreateTagsInput{
+		Resources: awssdk.StringSlice(ids),
+		Tags: []*ec2.Tag{{
+			Key:   awssdk.String(fmt.Sprintf("kubernetes.io/cluster/%s", infraID)),
+			Value: awssdk.String("shared"),
+		}},
+	})
+	return errors.Wrap(err, "failed to tag the subnets as shared")
+}
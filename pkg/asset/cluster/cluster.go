@@ -10,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster/aws"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/password"
 	"github.com/openshift/installer/pkg/terraform"
@@ -18,6 +19,9 @@ import (
 var (
 	// kubeadminPasswordPath is the path where kubeadmin user password is stored.
 	kubeadminPasswordPath = filepath.Join("auth", "kubeadmin-password")
+
+	// terraformApply launches the infrastructure.  It is replaced in tests.
+	terraformApply = terraform.Apply
 )
 
 // Cluster uses the terraform executable to launch a cluster
@@ -45,6 +49,7 @@ func (c *Cluster) Dependencies() []asset.Asset {
 		&installconfig.PlatformCredsCheck{},
 		&TerraformVariables{},
 		&password.KubeadminPassword{},
+		&failedApplyState{},
 	}
 }
 
@@ -54,7 +59,8 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 	installConfig := &installconfig.InstallConfig{}
 	terraformVariables := &TerraformVariables{}
 	kubeadminPassword := &password.KubeadminPassword{}
-	parents.Get(clusterID, installConfig, terraformVariables, kubeadminPassword)
+	failedApply := &failedApplyState{}
+	parents.Get(clusterID, installConfig, terraformVariables, kubeadminPassword, failedApply)
 
 	if installConfig.Config.Platform.None != nil {
 		return errors.New("cluster cannot be created with platform set to 'none'")
@@ -67,12 +73,9 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 	}
 	defer os.RemoveAll(tmpDir)
 
-	extraArgs := []string{}
-	for _, file := range terraformVariables.Files() {
-		if err := ioutil.WriteFile(filepath.Join(tmpDir, file.Filename), file.Data, 0600); err != nil {
-			return err
-		}
-		extraArgs = append(extraArgs, fmt.Sprintf("-var-file=%s", filepath.Join(tmpDir, file.Filename)))
+	extraArgs, err := writeVarFiles(tmpDir, terraformVariables)
+	if err != nil {
+		return err
 	}
 
 	c.FileList = []*asset.File{
@@ -82,8 +85,21 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 		},
 	}
 
-	logrus.Infof("Creating infrastructure resources...")
-	stateFile, err := terraform.Apply(tmpDir, installConfig.Config.Platform.Name(), extraArgs...)
+	if installConfig.Config.Platform.AWS != nil {
+		if err := aws.TagSharedSubnets(clusterID.InfraID, installConfig.Config); err != nil {
+			return err
+		}
+	}
+
+	if failedApply.File != nil {
+		if err := ioutil.WriteFile(filepath.Join(tmpDir, terraform.StateFileName), failedApply.File.Data, 0600); err != nil {
+			return err
+		}
+		logrus.Infof("Resuming the creation of infrastructure resources from a previously failed attempt...")
+	} else {
+		logrus.Infof("Creating infrastructure resources...")
+	}
+	stateFile, err := terraformApply(tmpDir, installConfig.Config.Platform.Name(), extraArgs...)
 	if err != nil {
 		err = errors.Wrap(err, "failed to create cluster")
 		if stateFile == "" {
@@ -91,7 +107,9 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 		}
 		// Store the error from the apply, but continue with the
 		// generation so that the Terraform state file is recovered from
-		// the temporary directory.
+		// the temporary directory, and mark it so that the next attempt
+		// resumes from it.
+		c.FileList = append(c.FileList, &asset.File{Filename: failedApplyFileName})
 	}
 
 	data, err2 := ioutil.ReadFile(stateFile)
@@ -115,7 +133,9 @@ func (c *Cluster) Files() []*asset.File {
 }
 
 // Load returns error if the tfstate file is already on-disk, because we want to
-// prevent user from accidentally re-launching the cluster.
+// prevent user from accidentally re-launching the cluster.  A tfstate file
+// left behind by a failed apply is not an error, because the cluster is
+// re-generated on top of it.
 func (c *Cluster) Load(f asset.FileFetcher) (found bool, err error) {
 	_, err = f.FetchByName(terraform.StateFileName)
 	if err != nil {
@@ -125,5 +145,25 @@ func (c *Cluster) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, err
 	}
 
+	_, err = f.FetchByName(failedApplyFileName)
+	if err == nil {
+		return false, nil
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
 	return true, errors.Errorf("%q already exists.  There may already be a running cluster", terraform.StateFileName)
 }
+
+// writeVarFiles writes the Terraform variable files into dir and returns
+// the arguments needed to pass them to Terraform.
+func writeVarFiles(dir string, terraformVariables *TerraformVariables) ([]string, error) {
+	extraArgs := []string{}
+	for _, file := range terraformVariables.Files() {
+		if err := ioutil.WriteFile(filepath.Join(dir, file.Filename), file.Data, 0600); err != nil {
+			return nil, err
+		}
+		extraArgs = append(extraArgs, fmt.Sprintf("-var-file=%s", filepath.Join(dir, file.Filename)))
+	}
+	return extraArgs, nil
+}
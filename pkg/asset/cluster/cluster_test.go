@@ -0,0 +1,203 @@
+package cluster
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/mock"
+	"github.com/openshift/installer/pkg/asset/password"
+	"github.com/openshift/installer/pkg/terraform"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/libvirt"
+)
+
+func TestClusterLoad(t *testing.T) {
+	cases := []struct {
+		name          string
+		stateError    error
+		markerError   error
+		expectedFound bool
+		expectedError bool
+	}{
+		{
+			name:       "no state",
+			stateError: &os.PathError{Err: os.ErrNotExist},
+		},
+		{
+			name:          "state without marker",
+			markerError:   &os.PathError{Err: os.ErrNotExist},
+			expectedFound: true,
+			expectedError: true,
+		},
+		{
+			name: "state from failed apply",
+		},
+		{
+			name:          "error fetching state",
+			stateError:    errors.New("fetch failed"),
+			expectedError: true,
+		},
+		{
+			name:          "error fetching marker",
+			markerError:   errors.New("fetch failed"),
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByName(terraform.StateFileName).
+				Return(&asset.File{Filename: terraform.StateFileName}, tc.stateError)
+			if tc.stateError == nil {
+				fileFetcher.EXPECT().FetchByName(failedApplyFileName).
+					Return(&asset.File{Filename: failedApplyFileName}, tc.markerError)
+			}
+
+			found, err := (&Cluster{}).Load(fileFetcher)
+			assert.Equal(t, tc.expectedFound, found, "unexpected found value returned from Load")
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFailedApplyStateLoad(t *testing.T) {
+	cases := []struct {
+		name          string
+		markerError   error
+		stateError    error
+		expectedFound bool
+	}{
+		{
+			name:          "state from failed apply",
+			expectedFound: true,
+		},
+		{
+			name:        "no marker",
+			markerError: &os.PathError{Err: os.ErrNotExist},
+		},
+		{
+			name:       "marker without state",
+			stateError: &os.PathError{Err: os.ErrNotExist},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			stateFile := &asset.File{Filename: terraform.StateFileName, Data: []byte("saved state")}
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByName(failedApplyFileName).
+				Return(&asset.File{Filename: failedApplyFileName}, tc.markerError)
+			if tc.markerError == nil {
+				fileFetcher.EXPECT().FetchByName(terraform.StateFileName).
+					Return(stateFile, tc.stateError)
+			}
+
+			state := &failedApplyState{}
+			found, err := state.Load(fileFetcher)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFound, found, "unexpected found value returned from Load")
+			if tc.expectedFound {
+				assert.Equal(t, stateFile, state.File)
+			} else {
+				assert.Nil(t, state.File)
+			}
+		})
+	}
+}
+
+func TestClusterGenerate(t *testing.T) {
+	cases := []struct {
+		name           string
+		savedState     *asset.File
+		applyError     error
+		expectedFiles  []string
+		expectedError  bool
+		expectedResume bool
+	}{
+		{
+			name:          "new cluster",
+			expectedFiles: []string{kubeadminPasswordPath, terraform.StateFileName},
+		},
+		{
+			name:           "resume from failed apply",
+			savedState:     &asset.File{Filename: terraform.StateFileName, Data: []byte("saved state")},
+			expectedFiles:  []string{kubeadminPasswordPath, terraform.StateFileName},
+			expectedResume: true,
+		},
+		{
+			name:          "failed apply",
+			applyError:    errors.New("apply failed"),
+			expectedFiles: []string{kubeadminPasswordPath, failedApplyFileName, terraform.StateFileName},
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(apply func(string, string, ...string) (string, error)) {
+				terraformApply = apply
+			}(terraformApply)
+			terraformApply = func(dir string, platform string, extraArgs ...string) (string, error) {
+				stateFile := filepath.Join(dir, terraform.StateFileName)
+				data, err := ioutil.ReadFile(stateFile)
+				if tc.expectedResume {
+					assert.NoError(t, err, "saved state was not written for Terraform")
+					assert.Equal(t, "saved state", string(data))
+				} else {
+					assert.True(t, os.IsNotExist(err), "unexpected state for Terraform")
+				}
+				if err := ioutil.WriteFile(stateFile, []byte("new state"), 0600); err != nil {
+					t.Fatal(err)
+				}
+				return stateFile, tc.applyError
+			}
+
+			parents := asset.Parents{}
+			parents.Add(
+				&installconfig.ClusterID{InfraID: "test-cluster-abcde"},
+				&installconfig.InstallConfig{
+					Config: &types.InstallConfig{
+						Platform: types.Platform{
+							Libvirt: &libvirt.Platform{},
+						},
+					},
+				},
+				&TerraformVariables{},
+				&password.KubeadminPassword{Password: "kubeadmin-password"},
+				&failedApplyState{File: tc.savedState},
+			)
+
+			cluster := &Cluster{}
+			err := cluster.Generate(parents)
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			filenames := []string{}
+			for _, f := range cluster.Files() {
+				filenames = append(filenames, f.Filename)
+				if f.Filename == terraform.StateFileName {
+					assert.Equal(t, "new state", string(f.Data))
+				}
+			}
+			assert.Equal(t, tc.expectedFiles, filenames)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package cluster
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+const (
+	// failedApplyFileName marks the Terraform state on disk as having been
+	// recovered from an apply that did not complete.
+	failedApplyFileName = ".openshift_install_failed_apply"
+)
+
+// failedApplyState is the Terraform state left behind by an earlier
+// 'create cluster' whose apply failed part way through.  Cluster applies
+// on top of it instead of refusing to run.
+type failedApplyState struct {
+	// File is never stored in the state file, so that only the state
+	// on disk is ever resumed.
+	File *asset.File `json:"-"`
+}
+
+var _ asset.WritableAsset = (*failedApplyState)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (s *failedApplyState) Name() string {
+	return "Terraform State From Failed Apply"
+}
+
+// Dependencies returns no dependencies.
+func (s *failedApplyState) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate is a no-op, because there is nothing to resume unless it was
+// found on disk.
+func (s *failedApplyState) Generate(asset.Parents) error {
+	return nil
+}
+
+// Files returns the Terraform state and its marker, so they are consumed
+// once the apply has been resumed.
+func (s *failedApplyState) Files() []*asset.File {
+	if s.File != nil {
+		return []*asset.File{s.File, {Filename: failedApplyFileName}}
+	}
+	return []*asset.File{}
+}
+
+// Load returns the Terraform state from disk if it is marked as coming
+// from a failed apply.
+func (s *failedApplyState) Load(f asset.FileFetcher) (found bool, err error) {
+	if _, err := f.FetchByName(failedApplyFileName); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	file, err := f.FetchByName(terraform.StateFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logrus.Warnf("Ignoring %q because %q does not exist", failedApplyFileName, terraform.StateFileName)
+			return false, nil
+		}
+		return false, err
+	}
+
+	s.File = file
+	return true, nil
+}
@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+// Plan uses the terraform executable to compute the infrastructure that
+// would be created for the cluster, without creating any of it.  It is not
+// a WritableAsset, and is fetched with Store.Peek, so that planning neither
+// consumes the assets in the target directory nor saves the state file.
+type Plan struct {
+	// Resources is the number of resources that would be created, keyed by
+	// resource type.
+	Resources map[string]int
+}
+
+var _ asset.Asset = (*Plan)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (p *Plan) Name() string {
+	return "Cluster Plan"
+}
+
+// Dependencies returns the direct dependency for planning the cluster.
+func (p *Plan) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+		// PlatformCredsCheck just checks the creds (and asks, if needed)
+		// Terraform still needs them to refresh data sources while planning.
+		&installconfig.PlatformCredsCheck{},
+		&TerraformVariables{},
+	}
+}
+
+// Generate runs 'terraform plan' and records the resources that would be
+// created.
+func (p *Plan) Generate(parents asset.Parents) (err error) {
+	installConfig := &installconfig.InstallConfig{}
+	terraformVariables := &TerraformVariables{}
+	parents.Get(installConfig, terraformVariables)
+
+	if installConfig.Config.Platform.None != nil {
+		return errors.New("cluster cannot be planned with platform set to 'none'")
+	}
+
+	tmpDir, err := ioutil.TempDir("", "openshift-install-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temp dir for terraform execution")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	extraArgs, err := writeVarFiles(tmpDir, terraformVariables)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Planning infrastructure resources...")
+	p.Resources, err = terraform.Plan(tmpDir, installConfig.Config.Platform.Name(), extraArgs...)
+	if err != nil {
+		return errors.Wrap(err, "failed to plan cluster")
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/ignition/bootstrap"
 	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/asset/machines"
 	"github.com/openshift/installer/pkg/asset/rhcos"
 	"github.com/openshift/installer/pkg/tfvars"
@@ -111,7 +112,27 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		for i, m := range masters {
 			masterConfigs[i] = m.Spec.ProviderSpec.Value.Object.(*awsprovider.AWSMachineProviderConfig)
 		}
-		data, err := awstfvars.TFVars(masterConfigs)
+		var vpc string
+		var privateSubnets, publicSubnets []string
+		if ids := installConfig.Config.Platform.AWS.Subnets; len(ids) > 0 {
+			subnets, err := awsconfig.GetSubnets(installConfig.Config.Platform.AWS.Region, ids)
+			if err != nil {
+				return errors.Wrap(err, "failed to fetch subnets")
+			}
+			for _, id := range ids {
+				subnet, ok := subnets[id]
+				if !ok {
+					return errors.Errorf("subnet %s not found", id)
+				}
+				vpc = subnet.VPC
+				if subnet.Public {
+					publicSubnets = append(publicSubnets, id)
+				} else {
+					privateSubnets = append(privateSubnets, id)
+				}
+			}
+		}
+		data, err := awstfvars.TFVars(vpc, privateSubnets, publicSubnets, masterConfigs)
 		if err != nil {
 			return errors.Wrapf(err, "failed to get %s Terraform variables", platform)
 		}
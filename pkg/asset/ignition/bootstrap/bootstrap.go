@@ -24,6 +24,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/kubeconfig"
 	"github.com/openshift/installer/pkg/asset/machines"
 	"github.com/openshift/installer/pkg/asset/manifests"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
 	"github.com/openshift/installer/pkg/asset/tls"
 	"github.com/openshift/installer/pkg/types"
 )
@@ -35,10 +36,6 @@ const (
 	ignitionUser         = "core"
 )
 
-var (
-	defaultReleaseImage = "registry.svc.ci.openshift.org/openshift/origin-release:v4.0"
-)
-
 // bootstrapTemplateData is the data to use to replace values in bootstrap
 // template files.
 type bootstrapTemplateData struct {
@@ -65,6 +62,7 @@ func (a *Bootstrap) Dependencies() []asset.Asset {
 		&machines.Master{},
 		&manifests.Manifests{},
 		&manifests.Openshift{},
+		&releaseimage.Image{},
 		&tls.AdminKubeConfigCABundle{},
 		&tls.AggregatorCA{},
 		&tls.AggregatorCABundle{},
@@ -114,9 +112,10 @@ func (a *Bootstrap) Dependencies() []asset.Asset {
 // Generate generates the ignition config for the Bootstrap asset.
 func (a *Bootstrap) Generate(dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
-	dependencies.Get(installConfig)
+	releaseImage := &releaseimage.Image{}
+	dependencies.Get(installConfig, releaseImage)
 
-	templateData, err := a.getTemplateData(installConfig.Config)
+	templateData, err := a.getTemplateData(installConfig.Config, releaseImage.PullSpec)
 	if err != nil {
 		return errors.Wrap(err, "failed to get bootstrap templates")
 	}
@@ -137,6 +136,16 @@ func (a *Bootstrap) Generate(dependencies asset.Parents) error {
 	}
 	a.addParentFiles(dependencies)
 
+	if installConfig.Config.Proxy != nil {
+		a.Config.Storage.Files = append(a.Config.Storage.Files, ignition.ProxyEnvironmentFile(installConfig.Config.Proxy))
+	}
+	if installConfig.Config.AdditionalTrustBundle != "" {
+		a.Config.Storage.Files = append(a.Config.Storage.Files, ignition.AdditionalTrustBundleFile(installConfig.Config.AdditionalTrustBundle))
+	}
+	if len(installConfig.Config.ImageContentSources) > 0 {
+		a.Config.Storage.Files = append(a.Config.Storage.Files, ignition.RegistriesConfFile(installConfig.Config.ImageContentSources))
+	}
+
 	a.Config.Passwd.Users = append(
 		a.Config.Passwd.Users,
 		igntypes.PasswdUser{Name: "core", SSHAuthorizedKeys: []igntypes.SSHAuthorizedKey{igntypes.SSHAuthorizedKey(installConfig.Config.SSHKey)}},
@@ -168,18 +177,12 @@ func (a *Bootstrap) Files() []*asset.File {
 }
 
 // getTemplateData returns the data to use to execute bootstrap templates.
-func (a *Bootstrap) getTemplateData(installConfig *types.InstallConfig) (*bootstrapTemplateData, error) {
+func (a *Bootstrap) getTemplateData(installConfig *types.InstallConfig, releaseImage string) (*bootstrapTemplateData, error) {
 	etcdEndpoints := make([]string, *installConfig.ControlPlane.Replicas)
 	for i := range etcdEndpoints {
 		etcdEndpoints[i] = fmt.Sprintf("https://etcd-%d.%s:2379", i, installConfig.ClusterDomain())
 	}
 
-	releaseImage := defaultReleaseImage
-	if ri, ok := os.LookupEnv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE"); ok && ri != "" {
-		logrus.Warn("Found override for ReleaseImage. Please be warned, this is not advised")
-		releaseImage = ri
-	}
-
 	return &bootstrapTemplateData{
 		EtcdCertSignerImage: etcdCertSignerImage,
 		PullSecret:          installConfig.PullSecret,
@@ -7,13 +7,14 @@ import (
 	ignition "github.com/coreos/ignition/config/v2_2/types"
 	"github.com/vincent-petithory/dataurl"
 
+	ignasset "github.com/openshift/installer/pkg/asset/ignition"
 	"github.com/openshift/installer/pkg/types"
 )
 
 // pointerIgnitionConfig generates a config which references the remote config
 // served by the machine config server.
 func pointerIgnitionConfig(installConfig *types.InstallConfig, rootCA []byte, role string) *ignition.Config {
-	return &ignition.Config{
+	config := &ignition.Config{
 		Ignition: ignition.Ignition{
 			Version: ignition.MaxVersion.String(),
 			Config: ignition.IgnitionConfig{
@@ -36,4 +37,15 @@ func pointerIgnitionConfig(installConfig *types.InstallConfig, rootCA []byte, ro
 			},
 		},
 	}
+	if installConfig.Proxy != nil {
+		// The proxy is needed to pull the images of the remote config.
+		config.Storage.Files = append(config.Storage.Files, ignasset.ProxyEnvironmentFile(installConfig.Proxy))
+	}
+	if installConfig.AdditionalTrustBundle != "" {
+		config.Storage.Files = append(config.Storage.Files, ignasset.AdditionalTrustBundleFile(installConfig.AdditionalTrustBundle))
+	}
+	if len(installConfig.ImageContentSources) > 0 {
+		config.Storage.Files = append(config.Storage.Files, ignasset.RegistriesConfFile(installConfig.ImageContentSources))
+	}
+	return config
 }
@@ -0,0 +1,91 @@
+package machine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vincent-petithory/dataurl"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// TestPointerIgnitionConfigFiles tests that the proxy settings, the
+// additional trust bundle and the mirrors are added to the pointer ignition
+// config.
+func TestPointerIgnitionConfigFiles(t *testing.T) {
+	cases := []struct {
+		name                  string
+		proxy                 *types.Proxy
+		additionalTrustBundle string
+		imageContentSources   []types.ImageContentSource
+		expected              map[string]string
+	}{
+		{
+			name:     "default",
+			expected: map[string]string{},
+		},
+		{
+			name: "http proxy",
+			proxy: &types.Proxy{
+				HTTPProxy: "http://proxy.example.com:3128",
+				NoProxy:   ".example.com,10.0.0.0/16",
+			},
+			expected: map[string]string{
+				"/etc/systemd/system.conf.d/10-default-env.conf": `[Manager]
+DefaultEnvironment="HTTP_PROXY=http://proxy.example.com:3128" "http_proxy=http://proxy.example.com:3128" "NO_PROXY=.example.com,10.0.0.0/16" "no_proxy=.example.com,10.0.0.0/16"
+`,
+			},
+		},
+		{
+			name:                  "additional trust bundle",
+			additionalTrustBundle: "test-bundle",
+			expected: map[string]string{
+				"/etc/pki/ca-trust/source/anchors/openshift-config-user-ca-bundle.crt": "test-bundle",
+			},
+		},
+		{
+			name: "image content sources",
+			imageContentSources: []types.ImageContentSource{{
+				Source:  "quay.io/openshift-release-dev/ocp-release",
+				Mirrors: []string{"registry.example.com:5000/ocp/release", "mirror.example.com/release"},
+			}},
+			expected: map[string]string{
+				"/etc/containers/registries.conf": `unqualified-search-registries = ["registry.access.redhat.com", "docker.io"]
+
+[[registry]]
+location = "quay.io/openshift-release-dev/ocp-release"
+mirror-by-digest-only = true
+
+[[registry.mirror]]
+location = "registry.example.com:5000/ocp/release"
+
+[[registry.mirror]]
+location = "mirror.example.com/release"
+`,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			installConfig := &types.InstallConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+				BaseDomain:            "test-domain",
+				Proxy:                 tc.proxy,
+				AdditionalTrustBundle: tc.additionalTrustBundle,
+				ImageContentSources:   tc.imageContentSources,
+			}
+			config := pointerIgnitionConfig(installConfig, []byte("test-ca"), "worker")
+			actual := map[string]string{}
+			for _, file := range config.Storage.Files {
+				contents, err := dataurl.DecodeString(file.Contents.Source)
+				if assert.NoError(t, err) {
+					actual[file.Path] = string(contents.Data)
+				}
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
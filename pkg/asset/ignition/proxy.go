@@ -0,0 +1,39 @@
+package ignition
+
+import (
+	"fmt"
+	"strings"
+
+	ignition "github.com/coreos/ignition/config/v2_2/types"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// proxyEnvironmentPath is the systemd drop-in which sets the default
+// environment of all services.
+const proxyEnvironmentPath = "/etc/systemd/system.conf.d/10-default-env.conf"
+
+// ProxyEnvironmentFile creates an ignition-config file which configures
+// systemd to pass the proxy settings to all services.  Both the upper and
+// lower case variables are set, since tools disagree on which they honor.
+func ProxyEnvironmentFile(proxy *types.Proxy) ignition.File {
+	var env []string
+	for _, v := range []struct {
+		name  string
+		value string
+	}{
+		{name: "HTTP_PROXY", value: proxy.HTTPProxy},
+		{name: "HTTPS_PROXY", value: proxy.HTTPSProxy},
+		{name: "NO_PROXY", value: proxy.NoProxy},
+	} {
+		if v.value == "" {
+			continue
+		}
+		env = append(env,
+			fmt.Sprintf("%q", v.name+"="+v.value),
+			fmt.Sprintf("%q", strings.ToLower(v.name)+"="+v.value),
+		)
+	}
+	contents := fmt.Sprintf("[Manager]\nDefaultEnvironment=%s\n", strings.Join(env, " "))
+	return FileFromString(proxyEnvironmentPath, "root", 0644, contents)
+}
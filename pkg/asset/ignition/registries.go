@@ -0,0 +1,34 @@
+package ignition
+
+import (
+	"bytes"
+	"fmt"
+
+	ignition "github.com/coreos/ignition/config/v2_2/types"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// registriesConfPath is the configuration of the container registries used
+// by CRI-O and podman.
+const registriesConfPath = "/etc/containers/registries.conf"
+
+// RegistriesConfFile creates an ignition-config file which configures the
+// given mirrors for the container runtimes.  The mirrors are only used for
+// images pulled by digest, like the images of the release payload.
+func RegistriesConfFile(sources []types.ImageContentSource) ignition.File {
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, `unqualified-search-registries = ["registry.access.redhat.com", "docker.io"]`)
+	for _, source := range sources {
+		fmt.Fprintln(buf)
+		fmt.Fprintln(buf, "[[registry]]")
+		fmt.Fprintf(buf, "location = %q\n", source.Source)
+		fmt.Fprintln(buf, "mirror-by-digest-only = true")
+		for _, mirror := range source.Mirrors {
+			fmt.Fprintln(buf)
+			fmt.Fprintln(buf, "[[registry.mirror]]")
+			fmt.Fprintf(buf, "location = %q\n", mirror)
+		}
+	}
+	return FileFromBytes(registriesConfPath, "root", 0644, buf.Bytes())
+}
@@ -0,0 +1,15 @@
+package ignition
+
+import (
+	ignition "github.com/coreos/ignition/config/v2_2/types"
+)
+
+// additionalTrustBundlePath is where the additional trust bundle is added to
+// the trust anchors of the nodes, where update-ca-trust picks it up.
+const additionalTrustBundlePath = "/etc/pki/ca-trust/source/anchors/openshift-config-user-ca-bundle.crt"
+
+// AdditionalTrustBundleFile creates an ignition-config file which adds the
+// given PEM bundle to the trust anchors.
+func AdditionalTrustBundleFile(bundle string) ignition.File {
+	return FileFromString(additionalTrustBundlePath, "root", 0644, bundle)
+}
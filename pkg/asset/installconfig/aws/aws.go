@@ -12,6 +12,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/defaults"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
+	"github.com/openshift/installer/pkg/redact"
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/aws/validation"
 	"github.com/openshift/installer/pkg/version"
@@ -23,6 +25,10 @@ import (
 
 // Platform collects AWS-specific configuration.
 func Platform() (*aws.Platform, error) {
+	if input.NonInteractive {
+		return platformFromInputs()
+	}
+
 	longRegions := make([]string, 0, len(validation.Regions))
 	shortRegions := make([]string, 0, len(validation.Regions))
 	for id, location := range validation.Regions {
@@ -86,22 +92,48 @@ func Platform() (*aws.Platform, error) {
 	}, nil
 }
 
+// platformFromInputs collects AWS-specific configuration without prompting.
+// The region defaults to the region of the AWS profile.  Missing
+// credentials are reported along with the other missing inputs, rather
+// than when they are first used.
+func platformFromInputs() (*aws.Platform, error) {
+	ssn := newSession()
+	if _, err := ssn.Config.Credentials.Get(); err == credentials.ErrNoValidProvidersFoundInChain {
+		input.MarkMissing(input.AWSAccessKeyID, input.AWSSecretAccessKey)
+	}
+
+	region, ok := input.AWSRegion.Lookup()
+	if !ok && ssn.Config.Region != nil {
+		region = *ssn.Config.Region
+	}
+	if region == "" {
+		input.MarkMissing(input.AWSRegion)
+		return &aws.Platform{}, nil
+	}
+	if _, ok := validation.Regions[region]; !ok {
+		return nil, errors.Errorf("invalid AWS region %q", region)
+	}
+
+	return &aws.Platform{
+		Region: region,
+	}, nil
+}
+
 // GetSession returns an AWS session by checking credentials
 // and, if no creds are found, asks for them and stores them on disk in a config file
 func GetSession() (*session.Session, error) {
-	ssn := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-	}))
-	ssn.Config.Credentials = credentials.NewChainCredentials([]credentials.Provider{
-		&credentials.EnvProvider{},
-		&credentials.SharedCredentialsProvider{},
-	})
-	_, err := ssn.Config.Credentials.Get()
+	ssn := newSession()
+	creds, err := ssn.Config.Credentials.Get()
 	if err == credentials.ErrNoValidProvidersFoundInChain {
 		err = getCredentials()
 		if err != nil {
 			return nil, err
 		}
+		creds, err = ssn.Config.Credentials.Get()
+	}
+	if err == nil {
+		redact.AddSecret(creds.SecretAccessKey)
+		redact.AddSecret(creds.SessionToken)
 	}
 	ssn.Handlers.Build.PushBackNamed(request.NamedHandler{
 		Name: "openshiftInstaller.OpenshiftInstallerUserAgentHandler",
@@ -110,7 +142,25 @@ func GetSession() (*session.Session, error) {
 	return ssn, nil
 }
 
+// newSession returns an AWS session with credentials from the environment
+// or the shared credentials file, which may not have any.
+func newSession() *session.Session {
+	ssn := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	ssn.Config.Credentials = credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvProvider{},
+		&credentials.SharedCredentialsProvider{},
+	})
+	return ssn
+}
+
 func getCredentials() error {
+	if input.NonInteractive {
+		input.MarkMissing(input.AWSAccessKeyID, input.AWSSecretAccessKey)
+		return input.Missing()
+	}
+
 	var keyID string
 	err := survey.Ask([]*survey.Question{
 		{
@@ -0,0 +1,330 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/validate"
+)
+
+// Subnet is an existing subnet that a cluster is installed into.
+type Subnet struct {
+	ID   string
+	VPC  string
+	Zone string
+	CIDR string
+
+	// VPCCIDRs are the CIDR blocks associated with the VPC of the subnet.
+	VPCCIDRs []string
+
+	// Public is true if the default route of the subnet is to an
+	// internet gateway.
+	Public bool
+
+	// NAT is true if the default route of the subnet is to a NAT gateway
+	// or instance.
+	NAT bool
+}
+
+// GetSubnets returns the subnets with the given IDs in the region, keyed by
+// their IDs.  Subnets that do not exist are missing from the map.
+func GetSubnets(region string, ids []string) (map[string]Subnet, error) {
+	ssn, err := GetSession()
+	if err != nil {
+		return nil, err
+	}
+	client := ec2.New(ssn, aws.NewConfig().WithRegion(region))
+
+	subnets, err := client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("subnet-id"),
+			Values: aws.StringSlice(ids),
+		}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describing subnets")
+	}
+	if len(subnets.Subnets) == 0 {
+		return map[string]Subnet{}, nil
+	}
+
+	vpcs := map[string]bool{}
+	for _, subnet := range subnets.Subnets {
+		vpcs[aws.StringValue(subnet.VpcId)] = true
+	}
+	var vpcIDs []string
+	for vpc := range vpcs {
+		vpcIDs = append(vpcIDs, vpc)
+	}
+	vpcsOutput, err := client.DescribeVpcs(&ec2.DescribeVpcsInput{
+		VpcIds: aws.StringSlice(vpcIDs),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describing VPCs")
+	}
+
+	var tables []*ec2.RouteTable
+	err = client.DescribeRouteTablesPages(
+		&ec2.DescribeRouteTablesInput{
+			Filters: []*ec2.Filter{{
+				Name:   aws.String("vpc-id"),
+				Values: aws.StringSlice(vpcIDs),
+			}},
+		},
+		func(results *ec2.DescribeRouteTablesOutput, lastPage bool) bool {
+			tables = append(tables, results.RouteTables...)
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "describing route tables")
+	}
+	return subnetsFromEC2(subnets.Subnets, vpcsOutput.Vpcs, tables), nil
+}
+
+// subnetsFromEC2 returns the subnets, with the CIDR blocks of their VPCs and
+// the default routes from the route tables that they are associated with, or
+// from the main route tables of their VPCs.
+func subnetsFromEC2(subnets []*ec2.Subnet, vpcs []*ec2.Vpc, tables []*ec2.RouteTable) map[string]Subnet {
+	vpcCIDRs := map[string][]string{}
+	for _, vpc := range vpcs {
+		id := aws.StringValue(vpc.VpcId)
+		for _, association := range vpc.CidrBlockAssociationSet {
+			if association.CidrBlockState == nil || aws.StringValue(association.CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated {
+				vpcCIDRs[id] = append(vpcCIDRs[id], aws.StringValue(association.CidrBlock))
+			}
+		}
+		if len(vpcCIDRs[id]) == 0 && vpc.CidrBlock != nil {
+			vpcCIDRs[id] = []string{aws.StringValue(vpc.CidrBlock)}
+		}
+	}
+
+	associated := map[string]*ec2.RouteTable{}
+	main := map[string]*ec2.RouteTable{}
+	for _, table := range tables {
+		for _, association := range table.Associations {
+			if aws.BoolValue(association.Main) {
+				main[aws.StringValue(table.VpcId)] = table
+			} else if association.SubnetId != nil {
+				associated[aws.StringValue(association.SubnetId)] = table
+			}
+		}
+	}
+
+	result := make(map[string]Subnet, len(subnets))
+	for _, s := range subnets {
+		subnet := Subnet{
+			ID:   aws.StringValue(s.SubnetId),
+			VPC:  aws.StringValue(s.VpcId),
+			Zone: aws.StringValue(s.AvailabilityZone),
+			CIDR: aws.StringValue(s.CidrBlock),
+
+			VPCCIDRs: vpcCIDRs[aws.StringValue(s.VpcId)],
+		}
+		table, ok := associated[subnet.ID]
+		if !ok {
+			table = main[subnet.VPC]
+		}
+		if table != nil {
+			for _, route := range table.Routes {
+				if aws.StringValue(route.DestinationCidrBlock) != "0.0.0.0/0" || aws.StringValue(route.State) == ec2.RouteStateBlackhole {
+					continue
+				}
+				switch {
+				case strings.HasPrefix(aws.StringValue(route.GatewayId), "igw-"):
+					subnet.Public = true
+				case route.NatGatewayId != nil, route.InstanceId != nil:
+					subnet.NAT = true
+				}
+			}
+		}
+		result[subnet.ID] = subnet
+	}
+	return result
+}
+
+// PrivateSubnets returns the IDs of the private subnets,
+// keyed by their availability zones.
+func PrivateSubnets(subnets map[string]Subnet) map[string]string {
+	private := map[string]string{}
+	for id, subnet := range subnets {
+		if !subnet.Public {
+			private[subnet.Zone] = id
+		}
+	}
+	return private
+}
+
+// Zones returns the sorted availability zones of the subnets.
+func Zones(subnets map[string]string) []string {
+	zones := make([]string, 0, len(subnets))
+	for zone := range subnets {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// ValidateSubnets checks that the existing subnets of the install config
+// are in the same VPC and within the machine CIDR, that they route to an
+// internet gateway or NAT, and that there is a private and a public subnet
+// in each availability zone of the machine pools.  It also checks the
+// networking of the install config against the CIDR blocks of the VPC, which
+// must contain the machine CIDR and must not overlap the service and cluster
+// networks.
+func ValidateSubnets(config *types.InstallConfig, subnets map[string]Subnet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("platform", "aws", "subnets")
+
+	var machineCIDR *net.IPNet
+	if config.Networking != nil && config.Networking.MachineCIDR != nil {
+		machineCIDR = &config.Networking.MachineCIDR.IPNet
+	}
+	var vpc, vpcSubnet string
+	var vpcCIDRs []string
+	private := map[string]string{}
+	public := map[string]string{}
+	for i, id := range config.Platform.AWS.Subnets {
+		subnet, ok := subnets[id]
+		if !ok {
+			allErrs = append(allErrs, field.NotFound(fldPath.Index(i), id))
+			continue
+		}
+		if vpc == "" {
+			vpc, vpcSubnet, vpcCIDRs = subnet.VPC, id, subnet.VPCCIDRs
+		} else if subnet.VPC != vpc {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), id, fmt.Sprintf("subnet is in %s, but %s is in %s", subnet.VPC, vpcSubnet, vpc)))
+		}
+		if machineCIDR != nil && !containsCIDR(machineCIDR, subnet.CIDR) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), id, fmt.Sprintf("subnet CIDR %s is not within the machine CIDR %s", subnet.CIDR, machineCIDR)))
+		}
+
+		zones, kind := private, "private"
+		switch {
+		case subnet.Public:
+			zones, kind = public, "public"
+		case !subnet.NAT:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), id, "subnet has no default route to an internet gateway or NAT"))
+			continue
+		}
+		if other, ok := zones[subnet.Zone]; ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), id, fmt.Sprintf("%s is also a %s subnet in %s", other, kind, subnet.Zone)))
+			continue
+		}
+		zones[subnet.Zone] = id
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validateVPCNetworking(config.Networking, vpc, vpcCIDRs)...)
+
+	if len(private) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, config.Platform.AWS.Subnets, "there are no private subnets to create the machines in"))
+	}
+	for _, zone := range Zones(private) {
+		if _, ok := public[zone]; !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath, config.Platform.AWS.Subnets, fmt.Sprintf("there is no public subnet in %s", zone)))
+		}
+	}
+
+	defaultPool := config.Platform.AWS.DefaultMachinePlatform
+	checkZones := func(pool *types.MachinePool, poolPath *field.Path) {
+		zones, zonesPath := poolZones(pool, poolPath, defaultPool)
+		for i, zone := range zones {
+			if _, ok := private[zone]; !ok {
+				allErrs = append(allErrs, field.Invalid(zonesPath.Index(i), zone, "there is no private subnet in the zone"))
+			}
+		}
+	}
+	checkZones(config.ControlPlane, field.NewPath("controlPlane"))
+	for i := range config.Compute {
+		checkZones(&config.Compute[i], field.NewPath("compute").Index(i))
+	}
+	return allErrs
+}
+
+// validateVPCNetworking checks that the machine CIDR is within one of the
+// CIDR blocks of the VPC, and that the service and cluster networks do not
+// overlap any of them.
+func validateVPCNetworking(n *types.Networking, vpc string, vpcCIDRs []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if n == nil {
+		return allErrs
+	}
+	fldPath := field.NewPath("networking")
+
+	var cidrs []*net.IPNet
+	for _, cidr := range vpcCIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		cidrs = append(cidrs, network)
+	}
+
+	if n.MachineCIDR != nil {
+		contained := false
+		for _, cidr := range cidrs {
+			if containsCIDR(cidr, n.MachineCIDR.String()) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("machineCIDR"), n.MachineCIDR.String(), fmt.Sprintf("machine CIDR is not within the CIDR blocks of %s: %s", vpc, strings.Join(vpcCIDRs, ", "))))
+		}
+	}
+	for i := range n.ServiceNetwork {
+		allErrs = append(allErrs, validateVPCOverlap(&n.ServiceNetwork[i].IPNet, "service network", fldPath.Child("serviceNetwork").Index(i), vpc, cidrs)...)
+	}
+	for i := range n.ClusterNetwork {
+		allErrs = append(allErrs, validateVPCOverlap(&n.ClusterNetwork[i].CIDR.IPNet, "cluster network", fldPath.Child("clusterNetwork").Index(i).Child("cidr"), vpc, cidrs)...)
+	}
+	return allErrs
+}
+
+// validateVPCOverlap checks that the named network does not overlap any of
+// the CIDR blocks of the VPC.
+func validateVPCOverlap(network *net.IPNet, name string, fldPath *field.Path, vpc string, cidrs []*net.IPNet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, cidr := range cidrs {
+		if validate.DoCIDRsOverlap(network, cidr) {
+			allErrs = append(allErrs, field.Invalid(fldPath, network.String(), fmt.Sprintf("%s must not overlap with %s CIDR %s", name, vpc, cidr)))
+		}
+	}
+	return allErrs
+}
+
+// poolZones returns the zones that the pool at poolPath was configured with,
+// either in the pool or in the default machine platform, and their path.
+func poolZones(pool *types.MachinePool, poolPath *field.Path, defaultPool *awstypes.MachinePool) ([]string, *field.Path) {
+	if pool != nil && pool.Platform.AWS != nil && len(pool.Platform.AWS.Zones) > 0 {
+		return pool.Platform.AWS.Zones, poolPath.Child("platform", "aws", "zones")
+	}
+	if defaultPool != nil && len(defaultPool.Zones) > 0 {
+		return defaultPool.Zones, field.NewPath("platform", "aws", "defaultMachinePlatform", "zones")
+	}
+	return nil, nil
+}
+
+// containsCIDR returns true if the network contains all the addresses of
+// the CIDR.
+func containsCIDR(network *net.IPNet, cidr string) bool {
+	_, subnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	networkOnes, networkBits := network.Mask.Size()
+	subnetOnes, subnetBits := subnet.Mask.Size()
+	return networkBits == subnetBits && networkOnes <= subnetOnes && network.Contains(subnet.IP)
+}
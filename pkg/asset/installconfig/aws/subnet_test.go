@@ -0,0 +1,178 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestSubnetsFromEC2(t *testing.T) {
+	subnets := []*ec2.Subnet{
+		{SubnetId: aws.String("subnet-private"), VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("us-east-1a"), CidrBlock: aws.String("10.0.0.0/20")},
+		{SubnetId: aws.String("subnet-public"), VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("us-east-1a"), CidrBlock: aws.String("10.0.16.0/20")},
+		{SubnetId: aws.String("subnet-isolated"), VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("us-east-1b"), CidrBlock: aws.String("10.0.32.0/20")},
+	}
+	vpcs := []*ec2.Vpc{{
+		VpcId:     aws.String("vpc-1"),
+		CidrBlock: aws.String("10.0.0.0/16"),
+		CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+			{CidrBlock: aws.String("10.0.0.0/16"), CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociated)}},
+			{CidrBlock: aws.String("10.1.0.0/16"), CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociated)}},
+			{CidrBlock: aws.String("10.2.0.0/16"), CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeDisassociated)}},
+		},
+	}}
+	tables := []*ec2.RouteTable{
+		{
+			VpcId:        aws.String("vpc-1"),
+			Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+			Routes: []*ec2.Route{
+				{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")},
+			},
+		},
+		{
+			VpcId:        aws.String("vpc-1"),
+			Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(false), SubnetId: aws.String("subnet-private")}},
+			Routes: []*ec2.Route{
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1")},
+			},
+		},
+		{
+			VpcId:        aws.String("vpc-1"),
+			Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(false), SubnetId: aws.String("subnet-isolated")}},
+			Routes: []*ec2.Route{
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-2"), State: aws.String(ec2.RouteStateBlackhole)},
+			},
+		},
+	}
+	vpcCIDRs := []string{"10.0.0.0/16", "10.1.0.0/16"}
+	expected := map[string]Subnet{
+		"subnet-private":  {ID: "subnet-private", VPC: "vpc-1", Zone: "us-east-1a", CIDR: "10.0.0.0/20", VPCCIDRs: vpcCIDRs, NAT: true},
+		"subnet-public":   {ID: "subnet-public", VPC: "vpc-1", Zone: "us-east-1a", CIDR: "10.0.16.0/20", VPCCIDRs: vpcCIDRs, Public: true},
+		"subnet-isolated": {ID: "subnet-isolated", VPC: "vpc-1", Zone: "us-east-1b", CIDR: "10.0.32.0/20", VPCCIDRs: vpcCIDRs},
+	}
+	assert.Equal(t, expected, subnetsFromEC2(subnets, vpcs, tables))
+}
+
+func TestValidateSubnets(t *testing.T) {
+	vpc1CIDRs := []string{"10.0.0.0/16", "10.1.0.0/16"}
+	subnets := map[string]Subnet{
+		"subnet-a-private":       {ID: "subnet-a-private", VPC: "vpc-1", Zone: "us-east-1a", CIDR: "10.0.0.0/20", VPCCIDRs: vpc1CIDRs, NAT: true},
+		"subnet-a-public":        {ID: "subnet-a-public", VPC: "vpc-1", Zone: "us-east-1a", CIDR: "10.0.16.0/20", VPCCIDRs: vpc1CIDRs, Public: true},
+		"subnet-b-private":       {ID: "subnet-b-private", VPC: "vpc-1", Zone: "us-east-1b", CIDR: "10.0.32.0/20", VPCCIDRs: vpc1CIDRs, NAT: true},
+		"subnet-b-public":        {ID: "subnet-b-public", VPC: "vpc-1", Zone: "us-east-1b", CIDR: "10.0.48.0/20", VPCCIDRs: vpc1CIDRs, Public: true},
+		"subnet-a-nat":           {ID: "subnet-a-nat", VPC: "vpc-1", Zone: "us-east-1a", CIDR: "10.0.64.0/20", VPCCIDRs: vpc1CIDRs, NAT: true},
+		"subnet-isolated":        {ID: "subnet-isolated", VPC: "vpc-1", Zone: "us-east-1c", CIDR: "10.0.80.0/20", VPCCIDRs: vpc1CIDRs},
+		"subnet-other-vpc":       {ID: "subnet-other-vpc", VPC: "vpc-2", Zone: "us-east-1c", CIDR: "10.0.96.0/20", VPCCIDRs: []string{"10.0.96.0/20"}, Public: true},
+		"subnet-outside-private": {ID: "subnet-outside-private", VPC: "vpc-1", Zone: "us-east-1d", CIDR: "10.1.16.0/20", VPCCIDRs: vpc1CIDRs, NAT: true},
+		"subnet-outside":         {ID: "subnet-outside", VPC: "vpc-1", Zone: "us-east-1d", CIDR: "10.1.0.0/20", VPCCIDRs: vpc1CIDRs, Public: true},
+	}
+	cases := []struct {
+		name       string
+		subnets    []string
+		zones      []string
+		networking *types.Networking
+		expected   []string
+	}{
+		{
+			name:    "valid",
+			subnets: []string{"subnet-a-private", "subnet-a-public", "subnet-b-private", "subnet-b-public"},
+			zones:   []string{"us-east-1b"},
+		},
+		{
+			name:    "invalid subnets",
+			subnets: []string{"subnet-a-private", "subnet-missing", "subnet-a-nat", "subnet-isolated", "subnet-other-vpc", "subnet-outside"},
+			expected: []string{
+				`platform.aws.subnets[1]: Not found: "subnet-missing"`,
+				`platform.aws.subnets[2]: Invalid value: "subnet-a-nat": subnet-a-private is also a private subnet in us-east-1a`,
+				`platform.aws.subnets[3]: Invalid value: "subnet-isolated": subnet has no default route to an internet gateway or NAT`,
+				`platform.aws.subnets[4]: Invalid value: "subnet-other-vpc": subnet is in vpc-2, but subnet-a-private is in vpc-1`,
+				`platform.aws.subnets[5]: Invalid value: "subnet-outside": subnet CIDR 10.1.0.0/20 is not within the machine CIDR 10.0.0.0/16`,
+			},
+		},
+		{
+			name:    "machine CIDR outside the VPC",
+			subnets: []string{"subnet-a-private", "subnet-a-public"},
+			networking: &types.Networking{
+				MachineCIDR: ipnet.MustParseCIDR("10.0.0.0/15"),
+			},
+			expected: []string{
+				`networking.machineCIDR: Invalid value: "10.0.0.0/15": machine CIDR is not within the CIDR blocks of vpc-1: 10.0.0.0/16, 10.1.0.0/16`,
+			},
+		},
+		{
+			name:    "machine CIDR in a secondary VPC CIDR block",
+			subnets: []string{"subnet-outside-private", "subnet-outside"},
+			networking: &types.Networking{
+				MachineCIDR: ipnet.MustParseCIDR("10.1.0.0/16"),
+			},
+		},
+		{
+			name:    "networks overlapping the VPC",
+			subnets: []string{"subnet-a-private", "subnet-a-public"},
+			networking: &types.Networking{
+				MachineCIDR:    ipnet.MustParseCIDR("10.0.0.0/16"),
+				ServiceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16"), *ipnet.MustParseCIDR("10.1.128.0/17")},
+				ClusterNetwork: []types.ClusterNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/14"), HostPrefix: 23}},
+			},
+			expected: []string{
+				`networking.serviceNetwork[1]: Invalid value: "10.1.128.0/17": service network must not overlap with vpc-1 CIDR 10.1.0.0/16`,
+				`networking.clusterNetwork[0].cidr: Invalid value: "10.0.0.0/14": cluster network must not overlap with vpc-1 CIDR 10.0.0.0/16`,
+				`networking.clusterNetwork[0].cidr: Invalid value: "10.0.0.0/14": cluster network must not overlap with vpc-1 CIDR 10.1.0.0/16`,
+			},
+		},
+		{
+			name:    "missing zones",
+			subnets: []string{"subnet-a-private", "subnet-b-private", "subnet-b-public"},
+			zones:   []string{"us-east-1b", "us-east-1c"},
+			expected: []string{
+				`platform.aws.subnets: Invalid value: []string{"subnet-a-private", "subnet-b-private", "subnet-b-public"}: there is no public subnet in us-east-1a`,
+				`compute[0].platform.aws.zones[1]: Invalid value: "us-east-1c": there is no private subnet in the zone`,
+			},
+		},
+		{
+			name:    "no private subnets",
+			subnets: []string{"subnet-a-public"},
+			expected: []string{
+				`platform.aws.subnets: Invalid value: []string{"subnet-a-public"}: there are no private subnets to create the machines in`,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			networking := tc.networking
+			if networking == nil {
+				networking = &types.Networking{
+					MachineCIDR: ipnet.MustParseCIDR("10.0.0.0/16"),
+				}
+			}
+			config := &types.InstallConfig{
+				Networking:   networking,
+				ControlPlane: &types.MachinePool{Name: "master"},
+				Compute: []types.MachinePool{{
+					Name: "worker",
+					Platform: types.MachinePoolPlatform{
+						AWS: &awstypes.MachinePool{Zones: tc.zones},
+					},
+				}},
+				Platform: types.Platform{
+					AWS: &awstypes.Platform{
+						Region:  "us-east-1",
+						Subnets: tc.subnets,
+					},
+				},
+			}
+			var errs []string
+			for _, err := range ValidateSubnets(config, subnets) {
+				errs = append(errs, err.Error())
+			}
+			assert.Equal(t, tc.expected, errs)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
 	"github.com/openshift/installer/pkg/validate"
 )
 
@@ -29,6 +30,18 @@ func (a *baseDomain) Generate(parents asset.Parents) error {
 	platform := &platform{}
 	parents.Get(platform)
 
+	if input.NonInteractive {
+		domain, ok := input.BaseDomain.Require()
+		if !ok {
+			return nil
+		}
+		if err := validate.DomainName(domain, true); err != nil {
+			return errors.Wrapf(err, "invalid base domain %q", domain)
+		}
+		a.BaseDomain = domain
+		return nil
+	}
+
 	if platform.AWS != nil {
 		var err error
 		a.BaseDomain, err = aws.GetBaseDomain()
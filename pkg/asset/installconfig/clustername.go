@@ -1,9 +1,11 @@
 package installconfig
 
 import (
+	"github.com/pkg/errors"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
 	"github.com/openshift/installer/pkg/types/validation"
 	"github.com/openshift/installer/pkg/validate"
 )
@@ -26,6 +28,22 @@ func (a *clusterName) Generate(parents asset.Parents) error {
 	bd := &baseDomain{}
 	parents.Get(bd)
 
+	if input.NonInteractive {
+		name, ok := input.ClusterName.Require()
+		if !ok {
+			return nil
+		}
+		// The base domain may be missing too, in which case the name is
+		// validated along with the rest of the install-config later.
+		if bd.BaseDomain != "" {
+			if err := validate.DomainName(validation.ClusterDomain(bd.BaseDomain, name), false); err != nil {
+				return errors.Wrapf(err, "invalid cluster name %q", name)
+			}
+		}
+		a.ClusterName = name
+		return nil
+	}
+
 	return survey.Ask([]*survey.Question{
 		{
 			Prompt: &survey.Input{
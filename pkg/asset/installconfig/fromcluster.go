@@ -0,0 +1,52 @@
+package installconfig
+
+import (
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/conversion"
+)
+
+const (
+	// ClusterConfigNamespace is the namespace of the ConfigMap that records
+	// the install-config of a cluster.
+	ClusterConfigNamespace = "kube-system"
+
+	// ClusterConfigName is the name of the ConfigMap that records the
+	// install-config of a cluster.
+	ClusterConfigName = "cluster-config-v1"
+
+	// clusterConfigKey is the key of the install-config in the ConfigMap.
+	clusterConfigKey = "install-config"
+
+	// PullSecretPlaceholder replaces the pull secret, which is redacted
+	// from the install-config recorded in the cluster.  It is not a valid
+	// pull secret, so the install-config cannot be used until the
+	// placeholder has been replaced.
+	PullSecretPlaceholder = "<replace with your pull secret>"
+)
+
+// FromClusterConfigMap reconstructs the install-config of a cluster from
+// its kube-system/cluster-config-v1 ConfigMap.  The install-config is
+// migrated to the current version, without the deprecated fields, and the
+// redacted pull secret is replaced by PullSecretPlaceholder.
+func FromClusterConfigMap(configMap *corev1.ConfigMap) (*types.InstallConfig, error) {
+	data, ok := configMap.Data[clusterConfigKey]
+	if !ok {
+		return nil, errors.Errorf("%s/%s has no %q key", configMap.Namespace, configMap.Name, clusterConfigKey)
+	}
+
+	config := &types.InstallConfig{}
+	if err := yaml.Unmarshal([]byte(data), config); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the install-config in %s/%s", configMap.Namespace, configMap.Name)
+	}
+
+	if _, err := conversion.Migrate(config); err != nil {
+		return nil, errors.Wrap(err, "failed to migrate install config")
+	}
+
+	config.PullSecret = PullSecretPlaceholder
+	return config, nil
+}
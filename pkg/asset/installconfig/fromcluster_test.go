@@ -0,0 +1,105 @@
+package installconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/none"
+)
+
+func TestFromClusterConfigMap(t *testing.T) {
+	cases := []struct {
+		name          string
+		data          map[string]string
+		expected      *types.InstallConfig
+		expectedError string
+	}{
+		{
+			name: "current version",
+			data: map[string]string{
+				"install-config": `apiVersion: v1beta4
+baseDomain: example.com
+metadata:
+  name: test-cluster
+platform:
+  none: {}
+pullSecret: ""
+sshKey: ssh-ed25519 AAAA
+`,
+			},
+			expected: func() *types.InstallConfig {
+				c := &types.InstallConfig{
+					TypeMeta:   metav1.TypeMeta{APIVersion: types.InstallConfigVersion},
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+					BaseDomain: "example.com",
+					SSHKey:     "ssh-ed25519 AAAA",
+					PullSecret: PullSecretPlaceholder,
+				}
+				c.Platform.None = &none.Platform{}
+				return c
+			}(),
+		},
+		{
+			name: "migrated",
+			data: map[string]string{
+				"install-config": `apiVersion: v1beta3
+baseDomain: example.com
+metadata:
+  name: test-cluster
+networking:
+  serviceCIDR: 172.30.0.0/16
+platform:
+  none: {}
+`,
+			},
+			expected: func() *types.InstallConfig {
+				c := &types.InstallConfig{
+					TypeMeta:   metav1.TypeMeta{APIVersion: types.InstallConfigVersion},
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+					BaseDomain: "example.com",
+					Networking: &types.Networking{
+						ServiceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16")},
+					},
+					PullSecret: PullSecretPlaceholder,
+				}
+				c.Platform.None = &none.Platform{}
+				return c
+			}(),
+		},
+		{
+			name:          "missing install-config",
+			data:          map[string]string{},
+			expectedError: `^kube-system/cluster-config-v1 has no "install-config" key$`,
+		},
+		{
+			name: "unsupported version",
+			data: map[string]string{
+				"install-config": "apiVersion: v1beta1\n",
+			},
+			expectedError: `^failed to migrate install config: cannot upconvert from version v1beta1$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ClusterConfigNamespace,
+					Name:      ClusterConfigName,
+				},
+				Data: tc.data,
+			}
+			config, err := FromClusterConfigMap(configMap)
+			if tc.expectedError != "" {
+				assert.Regexp(t, tc.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, config)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Package input provides the values that the install-config assets would
+// otherwise query from the user, for running the installer without a
+// terminal.
+package input
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// NonInteractive is true if the user must not be prompted.  Inputs are then
+// taken from command-line flags and environment variables only, and the
+// missing ones are collected so they can be reported together.
+var NonInteractive bool
+
+// Input is a value that is queried from the user in interactive mode.  It
+// implements pflag.Value, so that it can be set from the command line.
+type Input struct {
+	// Name is the human-friendly name of the input.
+	Name string
+
+	// Flag is the name of the command-line flag setting the input, or empty
+	// if the input can only be set from the environment.
+	Flag string
+
+	// Env is the environment variable setting the input.
+	Env string
+
+	// Usage is the help text for the flag.
+	Usage string
+
+	value string
+	set   bool
+}
+
+// The inputs of the install-config assets.
+var (
+	Platform = &Input{
+		Name:  "Platform",
+		Flag:  "platform",
+		Env:   "OPENSHIFT_INSTALL_PLATFORM",
+		Usage: "platform to install to (e.g. \"aws\")",
+	}
+	BaseDomain = &Input{
+		Name:  "Base Domain",
+		Flag:  "base-domain",
+		Env:   "OPENSHIFT_INSTALL_BASE_DOMAIN",
+		Usage: "base domain of the cluster",
+	}
+	ClusterName = &Input{
+		Name:  "Cluster Name",
+		Flag:  "cluster-name",
+		Env:   "OPENSHIFT_INSTALL_CLUSTER_NAME",
+		Usage: "name of the cluster",
+	}
+	PullSecretFile = &Input{
+		Name:  "Pull Secret",
+		Flag:  "pull-secret-file",
+		Env:   "OPENSHIFT_INSTALL_PULL_SECRET_FILE",
+		Usage: "path to the container registry pull secret",
+	}
+	SSHPublicKeyFile = &Input{
+		Name:  "SSH Public Key",
+		Flag:  "ssh-public-key-file",
+		Env:   "OPENSHIFT_INSTALL_SSH_PUBLIC_KEY_FILE",
+		Usage: "path to the SSH public key used to access the nodes (optional)",
+	}
+	AWSRegion = &Input{
+		Name:  "AWS Region",
+		Flag:  "aws-region",
+		Env:   "OPENSHIFT_INSTALL_AWS_REGION",
+		Usage: "AWS region to install to (defaults to the region of the AWS profile)",
+	}
+	AWSAccessKeyID = &Input{
+		Name: "AWS Access Key ID",
+		Env:  "AWS_ACCESS_KEY_ID",
+	}
+	AWSSecretAccessKey = &Input{
+		Name: "AWS Secret Access Key",
+		Env:  "AWS_SECRET_ACCESS_KEY",
+	}
+	LibvirtURI = &Input{
+		Name:  "Libvirt Connection URI",
+		Flag:  "libvirt-uri",
+		Env:   "OPENSHIFT_INSTALL_LIBVIRT_URI",
+		Usage: "libvirt connection URI (optional)",
+	}
+	OpenStackCloud = &Input{
+		Name:  "OpenStack Cloud",
+		Flag:  "openstack-cloud",
+		Env:   "OPENSHIFT_INSTALL_OPENSTACK_CLOUD",
+		Usage: "OpenStack cloud name from clouds.yaml",
+	}
+	OpenStackRegion = &Input{
+		Name:  "OpenStack Region",
+		Flag:  "openstack-region",
+		Env:   "OPENSHIFT_INSTALL_OPENSTACK_REGION",
+		Usage: "OpenStack region to install to (optional)",
+	}
+	OpenStackExternalNetwork = &Input{
+		Name:  "OpenStack External Network",
+		Flag:  "openstack-external-network",
+		Env:   "OPENSHIFT_INSTALL_OPENSTACK_EXTERNAL_NETWORK",
+		Usage: "OpenStack external network name",
+	}
+	OpenStackFlavor = &Input{
+		Name:  "OpenStack Flavor",
+		Flag:  "openstack-flavor",
+		Env:   "OPENSHIFT_INSTALL_OPENSTACK_FLAVOR",
+		Usage: "OpenStack compute flavor name",
+	}
+
+	// All are all of the inputs.
+	All = []*Input{
+		Platform,
+		BaseDomain,
+		ClusterName,
+		PullSecretFile,
+		SSHPublicKeyFile,
+		AWSRegion,
+		AWSAccessKeyID,
+		AWSSecretAccessKey,
+		LibvirtURI,
+		OpenStackCloud,
+		OpenStackRegion,
+		OpenStackExternalNetwork,
+		OpenStackFlavor,
+	}
+
+	missing []*Input
+)
+
+// String returns the value set from the command line.
+func (i *Input) String() string {
+	return i.value
+}
+
+// Set sets the value from the command line.
+func (i *Input) Set(value string) error {
+	i.value = value
+	i.set = true
+	return nil
+}
+
+// Type returns the type of the flag.
+func (i *Input) Type() string {
+	return "string"
+}
+
+// Lookup returns the value of the input, taken from the command line or
+// else from the environment, and whether it was set at all.
+func (i *Input) Lookup() (string, bool) {
+	if i.set {
+		return i.value, true
+	}
+	if i.Env == "" {
+		return "", false
+	}
+	value, ok := os.LookupEnv(i.Env)
+	return value, ok && value != ""
+}
+
+// Require returns the value of the input like Lookup.  If the input is not
+// set, it is recorded as missing, to be reported by Missing.
+func (i *Input) Require() (string, bool) {
+	value, ok := i.Lookup()
+	if !ok {
+		MarkMissing(i)
+	}
+	return value, ok
+}
+
+// describe returns the name of the input along with how to set it.
+func (i *Input) describe() string {
+	var sources []string
+	if i.Flag != "" {
+		sources = append(sources, "--"+i.Flag)
+	}
+	if i.Env != "" {
+		sources = append(sources, i.Env)
+	}
+	return fmt.Sprintf("%s (%s)", i.Name, strings.Join(sources, " or "))
+}
+
+// MarkMissing records the inputs as missing.
+func MarkMissing(inputs ...*Input) {
+	for _, i := range inputs {
+		if !isMissing(i) {
+			missing = append(missing, i)
+		}
+	}
+}
+
+func isMissing(i *Input) bool {
+	for _, m := range missing {
+		if m == i {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingError is returned when inputs required in non-interactive mode
+// have not been provided.
+type MissingError struct {
+	Inputs []*Input
+}
+
+func (e *MissingError) Error() string {
+	lines := make([]string, 0, len(e.Inputs)+1)
+	lines = append(lines, "missing inputs required in non-interactive mode:")
+	for _, i := range e.Inputs {
+		lines = append(lines, "  "+i.describe())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Missing returns a *MissingError listing the inputs recorded as missing so
+// far, or nil if there are none.
+func Missing() error {
+	if len(missing) == 0 {
+		return nil
+	}
+	return &MissingError{Inputs: append([]*Input(nil), missing...)}
+}
@@ -0,0 +1,87 @@
+package input
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		name          string
+		flag          *string
+		env           *string
+		expectedValue string
+		expectedOK    bool
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:          "env",
+			env:           strPtr("from-env"),
+			expectedValue: "from-env",
+			expectedOK:    true,
+		},
+		{
+			name: "empty env",
+			env:  strPtr(""),
+		},
+		{
+			name:          "flag",
+			flag:          strPtr("from-flag"),
+			expectedValue: "from-flag",
+			expectedOK:    true,
+		},
+		{
+			name:          "flag overrides env",
+			flag:          strPtr("from-flag"),
+			env:           strPtr("from-env"),
+			expectedValue: "from-flag",
+			expectedOK:    true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			in := &Input{Name: "Test", Flag: "test", Env: "OPENSHIFT_INSTALL_TEST_INPUT"}
+			os.Unsetenv(in.Env)
+			defer os.Unsetenv(in.Env)
+			if tc.env != nil {
+				os.Setenv(in.Env, *tc.env)
+			}
+			if tc.flag != nil {
+				in.Set(*tc.flag)
+			}
+			value, ok := in.Lookup()
+			assert.Equal(t, tc.expectedValue, value)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestMissing(t *testing.T) {
+	defer func() { missing = nil }()
+
+	a := &Input{Name: "A", Flag: "a", Env: "OPENSHIFT_INSTALL_TEST_A"}
+	b := &Input{Name: "B", Env: "OPENSHIFT_INSTALL_TEST_B"}
+	os.Unsetenv(a.Env)
+	os.Unsetenv(b.Env)
+
+	missing = nil
+	assert.NoError(t, Missing())
+
+	_, ok := a.Require()
+	assert.False(t, ok)
+	MarkMissing(b, a)
+
+	err := Missing()
+	assert.IsType(t, &MissingError{}, err)
+	assert.EqualError(t, err, `missing inputs required in non-interactive mode:
+  A (--a or OPENSHIFT_INSTALL_TEST_A)
+  B (OPENSHIFT_INSTALL_TEST_B)`)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -1,18 +1,24 @@
 package installconfig
 
 import (
+	"net/url"
 	"os"
 
 	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
+	"github.com/openshift/installer/pkg/explain"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/conversion"
 	"github.com/openshift/installer/pkg/types/defaults"
 	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
 	"github.com/openshift/installer/pkg/types/validation"
+	"github.com/openshift/installer/pkg/yamlpos"
 )
 
 const (
@@ -26,6 +32,7 @@ type InstallConfig struct {
 }
 
 var _ asset.WritableAsset = (*InstallConfig)(nil)
+var _ asset.SecretAsset = (*InstallConfig)(nil)
 
 // Dependencies returns all of the dependencies directly needed by an
 // InstallConfig asset.
@@ -54,6 +61,12 @@ func (a *InstallConfig) Generate(parents asset.Parents) error {
 		platform,
 	)
 
+	// In non-interactive mode, the parents do not fail on missing inputs,
+	// so that all of them can be reported at once.
+	if err := input.Missing(); err != nil {
+		return err
+	}
+
 	a.Config = &types.InstallConfig{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: types.InstallConfigVersion,
@@ -78,6 +91,9 @@ func (a *InstallConfig) Generate(parents asset.Parents) error {
 	if err := validation.ValidateInstallConfig(a.Config, openstackvalidation.NewValidValuesFetcher()).ToAggregate(); err != nil {
 		return errors.Wrap(err, "invalid install config")
 	}
+	for _, w := range validation.WarnInstallConfig(a.Config) {
+		logrus.Warnf("%s: %s", w.Field, w.Detail)
+	}
 
 	data, err := yaml.Marshal(a.Config)
 	if err != nil {
@@ -95,6 +111,26 @@ func (a *InstallConfig) Name() string {
 	return "Install Config"
 }
 
+// Secrets returns the pull secret and the passwords of the proxies.
+func (a *InstallConfig) Secrets() []string {
+	if a.Config == nil {
+		return nil
+	}
+	secrets := []string{a.Config.PullSecret}
+	if p := a.Config.Proxy; p != nil {
+		for _, proxy := range []string{p.HTTPProxy, p.HTTPSProxy} {
+			u, err := url.Parse(proxy)
+			if err != nil || u.User == nil {
+				continue
+			}
+			if password, ok := u.User.Password(); ok {
+				secrets = append(secrets, password)
+			}
+		}
+	}
+	return secrets
+}
+
 // Files returns the files generated by the asset.
 func (a *InstallConfig) Files() []*asset.File {
 	if a.File != nil {
@@ -103,6 +139,26 @@ func (a *InstallConfig) Files() []*asset.File {
 	return []*asset.File{}
 }
 
+// ValidateFields checks that the fields of install-config.yaml are in the
+// install-config schema.  Load ignores other fields or, if they only differ
+// in case, matches them to the field of the same name, so unknown fields are
+// errors unless lenient, in which case they are only logged as warnings.
+func ValidateFields(data []byte, lenient bool) error {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return errors.Wrap(err, "failed to unmarshal")
+	}
+	source := yamlpos.Parse(installConfigFilename, data)
+	_, unknown := explain.ValidateSchema(raw)
+	if lenient {
+		for _, err := range unknown {
+			logrus.Warnf("%s: %s: %s", source.Locate(err.Field), err.Field, err.Detail)
+		}
+		return nil
+	}
+	return source.Errors(unknown)
+}
+
 // Load returns the installconfig from disk.
 func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 	file, err := f.FetchByName(installConfigFilename)
@@ -113,6 +169,19 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, err
 	}
 
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(file.Data, &raw); err != nil {
+		return false, errors.Wrap(err, "failed to unmarshal")
+	}
+	// Errors point at the fields in install-config.yaml, although fields
+	// that were moved by the conversion are located at their parents.
+	source := yamlpos.Parse(installConfigFilename, file.Data)
+	// Fields that are not in the schema are checked with ValidateFields.
+	allErrs, _ := explain.ValidateSchema(raw)
+	if err := source.Errors(allErrs); err != nil {
+		return false, err
+	}
+
 	config := &types.InstallConfig{}
 	if err := yaml.Unmarshal(file.Data, config); err != nil {
 		return false, errors.Wrap(err, "failed to unmarshal")
@@ -128,8 +197,20 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, errors.Wrap(err, "failed to set defaults for install config")
 	}
 
-	if err := validation.ValidateInstallConfig(a.Config, openstackvalidation.NewValidValuesFetcher()).ToAggregate(); err != nil {
-		return false, errors.Wrapf(err, "invalid %q file", installConfigFilename)
+	if err := source.Errors(validation.ValidateInstallConfig(a.Config, openstackvalidation.NewValidValuesFetcher())); err != nil {
+		return false, err
+	}
+	if a.Config.Platform.AWS != nil && len(a.Config.Platform.AWS.Subnets) > 0 {
+		subnets, err := awsconfig.GetSubnets(a.Config.Platform.AWS.Region, a.Config.Platform.AWS.Subnets)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to fetch subnets")
+		}
+		if err := source.Errors(awsconfig.ValidateSubnets(a.Config, subnets)); err != nil {
+			return false, err
+		}
+	}
+	for _, w := range validation.WarnInstallConfig(a.Config) {
+		logrus.Warnf("%s: %s: %s", source.Locate(w.Field), w.Field, w.Detail)
 	}
 
 	data, err := yaml.Marshal(a.Config)
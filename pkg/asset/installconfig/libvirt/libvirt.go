@@ -2,8 +2,10 @@
 package libvirt
 
 import (
+	"github.com/pkg/errors"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	libvirtdefaults "github.com/openshift/installer/pkg/types/libvirt/defaults"
 	"github.com/openshift/installer/pkg/validate"
@@ -11,6 +13,19 @@ import (
 
 // Platform collects libvirt-specific configuration.
 func Platform() (*libvirt.Platform, error) {
+	if input.NonInteractive {
+		uri, ok := input.LibvirtURI.Lookup()
+		if !ok {
+			uri = libvirtdefaults.DefaultURI
+		}
+		if err := validate.URI(uri); err != nil {
+			return nil, errors.Wrapf(err, "invalid libvirt connection URI %q", uri)
+		}
+		return &libvirt.Platform{
+			URI: uri,
+		}, nil
+	}
+
 	var uri string
 	err := survey.Ask([]*survey.Question{
 		{
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
 	"github.com/openshift/installer/pkg/types/openstack"
 	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
 )
@@ -16,30 +17,25 @@ import (
 func Platform() (*openstack.Platform, error) {
 	validValuesFetcher := openstackvalidation.NewValidValuesFetcher()
 
+	if input.NonInteractive {
+		if _, ok := input.OpenStackCloud.Lookup(); !ok {
+			// Without a cloud, the valid values of the other inputs cannot
+			// be fetched, so just report which of them are missing.
+			input.MarkMissing(input.OpenStackCloud)
+			input.OpenStackExternalNetwork.Require()
+			input.OpenStackFlavor.Require()
+			return &openstack.Platform{}, nil
+		}
+	}
+
 	cloudNames, err := validValuesFetcher.GetCloudNames()
 	if err != nil {
 		return nil, err
 	}
-	// Sort cloudNames so we can use sort.SearchStrings
-	sort.Strings(cloudNames)
-	var cloud string
-	err = survey.Ask([]*survey.Question{
-		{
-			Prompt: &survey.Select{
-				Message: "Cloud",
-				Help:    "The OpenStack cloud name from clouds.yaml.",
-				Options: cloudNames,
-			},
-			Validate: survey.ComposeValidators(survey.Required, func(ans interface{}) error {
-				value := ans.(string)
-				i := sort.SearchStrings(cloudNames, value)
-				if i == len(cloudNames) || cloudNames[i] != value {
-					return errors.Errorf("invalid cloud name %q, should be one of %+v", value, strings.Join(cloudNames, ", "))
-				}
-				return nil
-			}),
-		},
-	}, &cloud)
+	cloud, err := choose(input.OpenStackCloud, &survey.Select{
+		Message: "Cloud",
+		Help:    "The OpenStack cloud name from clouds.yaml.",
+	}, "cloud name", cloudNames)
 	if err != nil {
 		return nil, err
 	}
@@ -48,26 +44,11 @@ func Platform() (*openstack.Platform, error) {
 	if err != nil {
 		return nil, err
 	}
-	sort.Strings(regionNames)
-	var region string
-	err = survey.Ask([]*survey.Question{
-		{
-			Prompt: &survey.Select{
-				Message: "Region",
-				Help:    "The OpenStack region to be used for installation.",
-				Default: "regionOne",
-				Options: regionNames,
-			},
-			Validate: survey.ComposeValidators(survey.Required, func(ans interface{}) error {
-				value := ans.(string)
-				i := sort.SearchStrings(regionNames, value)
-				if i == len(regionNames) || regionNames[i] != value {
-					return errors.Errorf("invalid region name %q, should be one of %+v", value, strings.Join(regionNames, ", "))
-				}
-				return nil
-			}),
-		},
-	}, &region)
+	region, err := choose(input.OpenStackRegion, &survey.Select{
+		Message: "Region",
+		Help:    "The OpenStack region to be used for installation.",
+		Default: "regionOne",
+	}, "region name", regionNames)
 	if err != nil {
 		return nil, err
 	}
@@ -76,25 +57,10 @@ func Platform() (*openstack.Platform, error) {
 	if err != nil {
 		return nil, err
 	}
-	sort.Strings(networkNames)
-	var extNet string
-	err = survey.Ask([]*survey.Question{
-		{
-			Prompt: &survey.Select{
-				Message: "ExternalNetwork",
-				Help:    "The OpenStack external network name to be used for installation.",
-				Options: networkNames,
-			},
-			Validate: survey.ComposeValidators(survey.Required, func(ans interface{}) error {
-				value := ans.(string)
-				i := sort.SearchStrings(networkNames, value)
-				if i == len(networkNames) || networkNames[i] != value {
-					return errors.Errorf("invalid network name %q, should be one of %+v", value, strings.Join(networkNames, ", "))
-				}
-				return nil
-			}),
-		},
-	}, &extNet)
+	extNet, err := choose(input.OpenStackExternalNetwork, &survey.Select{
+		Message: "ExternalNetwork",
+		Help:    "The OpenStack external network name to be used for installation.",
+	}, "network name", networkNames)
 	if err != nil {
 		return nil, err
 	}
@@ -103,25 +69,10 @@ func Platform() (*openstack.Platform, error) {
 	if err != nil {
 		return nil, err
 	}
-	sort.Strings(flavorNames)
-	var flavor string
-	err = survey.Ask([]*survey.Question{
-		{
-			Prompt: &survey.Select{
-				Message: "FlavorName",
-				Help:    "The OpenStack compute flavor to use for servers. A flavor with at least 4 GB RAM is recommended.",
-				Options: flavorNames,
-			},
-			Validate: survey.ComposeValidators(survey.Required, func(ans interface{}) error {
-				value := ans.(string)
-				i := sort.SearchStrings(flavorNames, value)
-				if i == len(flavorNames) || flavorNames[i] != value {
-					return errors.Errorf("invalid flavor name %q, should be one of %+v", value, strings.Join(flavorNames, ", "))
-				}
-				return nil
-			}),
-		},
-	}, &flavor)
+	flavor, err := choose(input.OpenStackFlavor, &survey.Select{
+		Message: "FlavorName",
+		Help:    "The OpenStack compute flavor to use for servers. A flavor with at least 4 GB RAM is recommended.",
+	}, "flavor name", flavorNames)
 	if err != nil {
 		return nil, err
 	}
@@ -147,3 +98,42 @@ func Platform() (*openstack.Platform, error) {
 		TrunkSupport:    trunkSupport,
 	}, nil
 }
+
+// choose asks the user to select one of the options with the given
+// prompt.  In non-interactive mode, the value is taken from in instead,
+// falling back to the prompt's default.  The value is rejected with an
+// error naming it as a "<noun>" if it is not one of the options.
+func choose(in *input.Input, prompt *survey.Select, noun string, options []string) (string, error) {
+	// Sort options so we can use sort.SearchStrings
+	sort.Strings(options)
+	validator := func(ans interface{}) error {
+		value := ans.(string)
+		i := sort.SearchStrings(options, value)
+		if i == len(options) || options[i] != value {
+			return errors.Errorf("invalid %s %q, should be one of %+v", noun, value, strings.Join(options, ", "))
+		}
+		return nil
+	}
+
+	if input.NonInteractive {
+		value, ok := in.Lookup()
+		if !ok {
+			if prompt.Default == "" {
+				input.MarkMissing(in)
+				return "", nil
+			}
+			value = prompt.Default
+		}
+		return value, validator(value)
+	}
+
+	prompt.Options = options
+	var value string
+	err := survey.Ask([]*survey.Question{
+		{
+			Prompt:   prompt,
+			Validate: survey.ComposeValidators(survey.Required, validator),
+		},
+	}, &value)
+	return value, err
+}
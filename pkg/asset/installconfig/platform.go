@@ -9,6 +9,7 @@ import (
 
 	"github.com/openshift/installer/pkg/asset"
 	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
 	libvirtconfig "github.com/openshift/installer/pkg/asset/installconfig/libvirt"
 	openstackconfig "github.com/openshift/installer/pkg/asset/installconfig/openstack"
 	"github.com/openshift/installer/pkg/types"
@@ -31,9 +32,19 @@ func (a *platform) Dependencies() []asset.Asset {
 
 // Generate queries for input from the user.
 func (a *platform) Generate(asset.Parents) error {
-	platform, err := a.queryUserForPlatform()
-	if err != nil {
-		return err
+	var platform string
+	var err error
+	if input.NonInteractive {
+		var ok bool
+		platform, ok = input.Platform.Require()
+		if !ok {
+			return nil
+		}
+	} else {
+		platform, err = a.queryUserForPlatform()
+		if err != nil {
+			return err
+		}
 	}
 
 	switch platform {
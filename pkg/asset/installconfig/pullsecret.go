@@ -1,9 +1,13 @@
 package installconfig
 
 import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
 	"github.com/openshift/installer/pkg/validate"
 )
 
@@ -11,7 +15,7 @@ type pullSecret struct {
 	PullSecret string
 }
 
-var _ asset.Asset = (*pullSecret)(nil)
+var _ asset.SecretAsset = (*pullSecret)(nil)
 
 // Dependencies returns no dependencies.
 func (a *pullSecret) Dependencies() []asset.Asset {
@@ -20,6 +24,22 @@ func (a *pullSecret) Dependencies() []asset.Asset {
 
 // Generate queries for the pull secret from the user.
 func (a *pullSecret) Generate(asset.Parents) error {
+	if input.NonInteractive {
+		path, ok := input.PullSecretFile.Require()
+		if !ok {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, "failed to read pull secret")
+		}
+		if err := validate.ImagePullSecret(string(data)); err != nil {
+			return errors.Wrapf(err, "invalid pull secret in %q", path)
+		}
+		a.PullSecret = string(data)
+		return nil
+	}
+
 	return survey.Ask([]*survey.Question{
 		{
 			Prompt: &survey.Password{
@@ -37,3 +57,8 @@ func (a *pullSecret) Generate(asset.Parents) error {
 func (a *pullSecret) Name() string {
 	return "Pull Secret"
 }
+
+// Secrets returns the pull secret.
+func (a *pullSecret) Secrets() []string {
+	return []string{a.PullSecret}
+}
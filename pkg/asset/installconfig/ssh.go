@@ -11,6 +11,7 @@ import (
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig/input"
 	"github.com/openshift/installer/pkg/validate"
 )
 
@@ -47,6 +48,20 @@ func readSSHKey(path string) (string, error) {
 
 // Generate generates the SSH public key asset.
 func (a *sshPublicKey) Generate(asset.Parents) error {
+	if input.NonInteractive {
+		// The SSH key is optional, so it is never missing.
+		path, ok := input.SSHPublicKeyFile.Lookup()
+		if !ok {
+			return nil
+		}
+		key, err := readSSHKey(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read SSH public key from %q", path)
+		}
+		a.Key = key
+		return nil
+	}
+
 	pubKeys := map[string]string{
 		noSSHKey: "",
 	}
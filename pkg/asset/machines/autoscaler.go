@@ -0,0 +1,136 @@
+package machines
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// machineAutoscaler is the autoscaling.openshift.io MachineAutoscaler, which
+// is not vendored.
+type machineAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec machineAutoscalerSpec `json:"spec"`
+}
+
+type machineAutoscalerSpec struct {
+	MinReplicas    int32                       `json:"minReplicas"`
+	MaxReplicas    int32                       `json:"maxReplicas"`
+	ScaleTargetRef crossVersionObjectReference `json:"scaleTargetRef"`
+}
+
+type crossVersionObjectReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+// DeepCopyObject implements runtime.Object, so that the autoscaler can be
+// added to a list.
+func (a *machineAutoscaler) DeepCopyObject() runtime.Object {
+	c := *a
+	a.ObjectMeta.DeepCopyInto(&c.ObjectMeta)
+	return &c
+}
+
+// clusterAutoscaler is the autoscaling.openshift.io ClusterAutoscaler, which
+// is not vendored.
+type clusterAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec clusterAutoscalerSpec `json:"spec"`
+}
+
+type clusterAutoscalerSpec struct {
+	ResourceLimits resourceLimits `json:"resourceLimits"`
+}
+
+type resourceLimits struct {
+	MaxNodesTotal int32 `json:"maxNodesTotal"`
+}
+
+// machineAutoscalers returns a machine autoscaler for each of the machine
+// sets of an autoscaled pool.  The bounds of the pool are split across the
+// machine sets like the replicas are, so machine sets whose maximum ends up
+// zero are not autoscaled.
+func machineAutoscalers(pool *types.MachinePool, sets []runtime.Object) ([]runtime.Object, error) {
+	var autoscalers []runtime.Object
+	for idx, set := range sets {
+		setMeta, err := meta.Accessor(set)
+		if err != nil {
+			return nil, err
+		}
+		gvk := set.GetObjectKind().GroupVersionKind()
+		apiVersion, kind := gvk.ToAPIVersionAndKind()
+		maxReplicas := splitReplicas(pool.Autoscaling.MaxReplicas, len(sets), idx)
+		if maxReplicas == 0 {
+			continue
+		}
+		autoscalers = append(autoscalers, &machineAutoscaler{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "autoscaling.openshift.io/v1beta1",
+				Kind:       "MachineAutoscaler",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: setMeta.GetNamespace(),
+				Name:      setMeta.GetName(),
+			},
+			Spec: machineAutoscalerSpec{
+				MinReplicas: splitReplicas(pool.Autoscaling.MinReplicas, len(sets), idx),
+				MaxReplicas: maxReplicas,
+				ScaleTargetRef: crossVersionObjectReference{
+					APIVersion: apiVersion,
+					Kind:       kind,
+					Name:       setMeta.GetName(),
+				},
+			},
+		})
+	}
+	return autoscalers, nil
+}
+
+// newClusterAutoscaler returns the cluster autoscaler, limiting the cluster
+// to the largest number of nodes the machine pools may have.
+func newClusterAutoscaler(config *types.InstallConfig) *clusterAutoscaler {
+	var maxNodes int64
+	if config.ControlPlane != nil && config.ControlPlane.Replicas != nil {
+		maxNodes += *config.ControlPlane.Replicas
+	}
+	for _, pool := range config.Compute {
+		switch {
+		case pool.Autoscaling != nil:
+			maxNodes += pool.Autoscaling.MaxReplicas
+		case pool.Replicas != nil:
+			maxNodes += *pool.Replicas
+		}
+	}
+	return &clusterAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling.openshift.io/v1",
+			Kind:       "ClusterAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+		Spec: clusterAutoscalerSpec{
+			ResourceLimits: resourceLimits{
+				MaxNodesTotal: int32(maxNodes),
+			},
+		},
+	}
+}
+
+// splitReplicas returns the share of total for the machine set at idx out
+// of count, handing the remainder out to the first machine sets.
+func splitReplicas(total int64, count int, idx int) int32 {
+	replicas := int32(total / int64(count))
+	if int64(idx) < total%int64(count) {
+		replicas++
+	}
+	return replicas
+}
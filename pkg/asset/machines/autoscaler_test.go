@@ -0,0 +1,89 @@
+package machines
+
+import (
+	"fmt"
+	"testing"
+
+	machineapi "github.com/openshift/cluster-api/pkg/apis/machine/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestMachineAutoscalers(t *testing.T) {
+	cases := []struct {
+		name        string
+		autoscaling types.MachinePoolAutoscaling
+		sets        int
+		expected    [][2]int32
+	}{
+		{
+			name:        "single machineset",
+			autoscaling: types.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 5},
+			sets:        1,
+			expected:    [][2]int32{{1, 5}},
+		},
+		{
+			name:        "split across machinesets",
+			autoscaling: types.MachinePoolAutoscaling{MinReplicas: 2, MaxReplicas: 7},
+			sets:        3,
+			expected:    [][2]int32{{1, 3}, {1, 2}, {0, 2}},
+		},
+		{
+			name:        "machinesets without maximum are skipped",
+			autoscaling: types.MachinePoolAutoscaling{MinReplicas: 0, MaxReplicas: 2},
+			sets:        3,
+			expected:    [][2]int32{{0, 1}, {0, 1}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := &types.MachinePool{Name: "worker", Autoscaling: &tc.autoscaling}
+			sets := make([]runtime.Object, tc.sets)
+			for i := range sets {
+				sets[i] = &machineapi.MachineSet{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "machine.openshift.io/v1beta1",
+						Kind:       "MachineSet",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "openshift-machine-api",
+						Name:      fmt.Sprintf("worker-%d", i),
+					},
+				}
+			}
+			autoscalers, err := machineAutoscalers(pool, sets)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := make([][2]int32, len(autoscalers))
+			for i, object := range autoscalers {
+				a := object.(*machineAutoscaler)
+				name := fmt.Sprintf("worker-%d", i)
+				actual[i] = [2]int32{a.Spec.MinReplicas, a.Spec.MaxReplicas}
+				assert.Equal(t, name, a.Name)
+				assert.Equal(t, "openshift-machine-api", a.Namespace)
+				assert.Equal(t, crossVersionObjectReference{
+					APIVersion: "machine.openshift.io/v1beta1",
+					Kind:       "MachineSet",
+					Name:       name,
+				}, a.Spec.ScaleTargetRef)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestClusterAutoscaler(t *testing.T) {
+	three, two := int64(3), int64(2)
+	config := &types.InstallConfig{
+		ControlPlane: &types.MachinePool{Name: "master", Replicas: &three},
+		Compute: []types.MachinePool{
+			{Name: "worker", Replicas: &three, Autoscaling: &types.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 10}},
+			{Name: "infra", Replicas: &two},
+		},
+	}
+	assert.Equal(t, int32(15), newClusterAutoscaler(config).Spec.ResourceLimits.MaxNodesTotal)
+}
@@ -17,8 +17,9 @@ import (
 	"github.com/openshift/installer/pkg/types/aws"
 )
 
-// Machines returns a list of machines for a machinepool.
-func Machines(clusterID string, config *types.InstallConfig, pool *types.MachinePool, osImage, role, userDataSecret string) ([]machineapi.Machine, error) {
+// Machines returns a list of machines for a machinepool.  The subnets are
+// the existing private subnets keyed by availability zone, if any.
+func Machines(clusterID string, config *types.InstallConfig, pool *types.MachinePool, subnets map[string]string, osImage, role, userDataSecret string) ([]machineapi.Machine, error) {
 	if configPlatform := config.Platform.Name(); configPlatform != aws.Name {
 		return nil, fmt.Errorf("non-AWS configuration: %q", configPlatform)
 	}
@@ -36,7 +37,7 @@ func Machines(clusterID string, config *types.InstallConfig, pool *types.Machine
 	var machines []machineapi.Machine
 	for idx := int64(0); idx < total; idx++ {
 		azIndex := int(idx) % len(azs)
-		provider, err := provider(clusterID, platform, mpool, osImage, azIndex, role, userDataSecret)
+		provider, err := provider(clusterID, platform, mpool, subnets, osImage, azIndex, role, userDataSecret)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create provider")
 		}
@@ -68,13 +69,22 @@ func Machines(clusterID string, config *types.InstallConfig, pool *types.Machine
 	return machines, nil
 }
 
-func provider(clusterID string, platform *aws.Platform, mpool *aws.MachinePool, osImage string, azIdx int, role, userDataSecret string) (*awsprovider.AWSMachineProviderConfig, error) {
+func provider(clusterID string, platform *aws.Platform, mpool *aws.MachinePool, subnets map[string]string, osImage string, azIdx int, role, userDataSecret string) (*awsprovider.AWSMachineProviderConfig, error) {
 	az := mpool.Zones[azIdx]
 	amiID := osImage
 	tags, err := tagsFromUserTags(clusterID, platform.UserTags)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create awsprovider.TagSpecifications from UserTags")
 	}
+	subnet := awsprovider.AWSResourceReference{
+		Filters: []awsprovider.Filter{{
+			Name:   "tag:Name",
+			Values: []string{fmt.Sprintf("%s-private-%s", clusterID, az)},
+		}},
+	}
+	if id, ok := subnets[az]; ok {
+		subnet = awsprovider.AWSResourceReference{ID: pointer.StringPtr(id)}
+	}
 	return &awsprovider.AWSMachineProviderConfig{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "awsproviderconfig.openshift.io/v1beta1",
@@ -95,13 +105,8 @@ func provider(clusterID string, platform *aws.Platform, mpool *aws.MachinePool,
 		IAMInstanceProfile: &awsprovider.AWSResourceReference{ID: pointer.StringPtr(fmt.Sprintf("%s-%s-profile", clusterID, role))},
 		UserDataSecret:     &corev1.LocalObjectReference{Name: userDataSecret},
 		CredentialsSecret:  &corev1.LocalObjectReference{Name: "aws-cloud-credentials"},
-		Subnet: awsprovider.AWSResourceReference{
-			Filters: []awsprovider.Filter{{
-				Name:   "tag:Name",
-				Values: []string{fmt.Sprintf("%s-private-%s", clusterID, az)},
-			}},
-		},
-		Placement: awsprovider.Placement{Region: platform.Region, AvailabilityZone: az},
+		Subnet:             subnet,
+		Placement:          awsprovider.Placement{Region: platform.Region, AvailabilityZone: az},
 		SecurityGroups: []awsprovider.AWSResourceReference{{
 			Filters: []awsprovider.Filter{{
 				Name:   "tag:Name",
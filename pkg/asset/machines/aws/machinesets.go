@@ -13,8 +13,9 @@ import (
 	"github.com/pkg/errors"
 )
 
-// MachineSets returns a list of machinesets for a machinepool.
-func MachineSets(clusterID string, config *types.InstallConfig, pool *types.MachinePool, osImage, role, userDataSecret string) ([]*machineapi.MachineSet, error) {
+// MachineSets returns a list of machinesets for a machinepool.  The subnets
+// are the existing private subnets keyed by availability zone, if any.
+func MachineSets(clusterID string, config *types.InstallConfig, pool *types.MachinePool, subnets map[string]string, osImage, role, userDataSecret string) ([]*machineapi.MachineSet, error) {
 	if configPlatform := config.Platform.Name(); configPlatform != aws.Name {
 		return nil, fmt.Errorf("non-AWS configuration: %q", configPlatform)
 	}
@@ -37,7 +38,7 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 			replicas++
 		}
 
-		provider, err := provider(clusterID, platform, mpool, osImage, idx, role, userDataSecret)
+		provider, err := provider(clusterID, platform, mpool, subnets, osImage, idx, role, userDataSecret)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create provider")
 		}
@@ -74,6 +75,10 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 						},
 					},
 					Spec: machineapi.MachineSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: pool.Labels,
+						},
+						Taints: pool.Taints,
 						ProviderSpec: machineapi.ProviderSpec{
 							Value: &runtime.RawExtension{Object: provider},
 						},
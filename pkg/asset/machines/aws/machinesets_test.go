@@ -0,0 +1,89 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	awsprovider "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsproviderconfig/v1beta1"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestMachineSets(t *testing.T) {
+	config := &types.InstallConfig{
+		Platform: types.Platform{
+			AWS: &aws.Platform{
+				Region: "us-east-1",
+			},
+		},
+	}
+	pool := &types.MachinePool{
+		Name:     "infra",
+		Replicas: pointer.Int64Ptr(3),
+		Labels:   map[string]string{"node-role.kubernetes.io/infra": ""},
+		Taints: []corev1.Taint{{
+			Key:    "node-role.kubernetes.io/infra",
+			Effect: corev1.TaintEffectNoSchedule,
+		}},
+		Platform: types.MachinePoolPlatform{
+			AWS: &aws.MachinePool{
+				InstanceType: "m4.large",
+				Zones:        []string{"us-east-1a", "us-east-1b"},
+			},
+		},
+	}
+
+	sets, err := MachineSets("test-cluster-abcde", config, pool, nil, "ami-0123456789", "worker", "worker-user-data")
+	if !assert.NoError(t, err) {
+		return
+	}
+	var names []string
+	var replicas []int32
+	for _, set := range sets {
+		names = append(names, set.Name)
+		replicas = append(replicas, *set.Spec.Replicas)
+		assert.Equal(t, pool.Labels, set.Spec.Template.Spec.Labels, set.Name)
+		assert.Equal(t, pool.Taints, set.Spec.Template.Spec.Taints, set.Name)
+	}
+	assert.Equal(t, []string{"test-cluster-abcde-infra-us-east-1a", "test-cluster-abcde-infra-us-east-1b"}, names)
+	assert.Equal(t, []int32{2, 1}, replicas)
+}
+
+func TestMachineSetsExistingSubnets(t *testing.T) {
+	config := &types.InstallConfig{
+		Platform: types.Platform{
+			AWS: &aws.Platform{
+				Region:  "us-east-1",
+				Subnets: []string{"subnet-a", "subnet-b", "subnet-public"},
+			},
+		},
+	}
+	pool := &types.MachinePool{
+		Name:     "worker",
+		Replicas: pointer.Int64Ptr(2),
+		Platform: types.MachinePoolPlatform{
+			AWS: &aws.MachinePool{
+				InstanceType: "m4.large",
+				Zones:        []string{"us-east-1a", "us-east-1b"},
+			},
+		},
+	}
+	subnets := map[string]string{"us-east-1a": "subnet-a", "us-east-1b": "subnet-b"}
+
+	sets, err := MachineSets("test-cluster-abcde", config, pool, subnets, "ami-0123456789", "worker", "worker-user-data")
+	if !assert.NoError(t, err) {
+		return
+	}
+	var ids []string
+	for _, set := range sets {
+		provider := set.Spec.Template.Spec.ProviderSpec.Value.Object.(*awsprovider.AWSMachineProviderConfig)
+		assert.Empty(t, provider.Subnet.Filters, set.Name)
+		if assert.NotNil(t, provider.Subnet.ID, set.Name) {
+			ids = append(ids, *provider.Subnet.ID)
+		}
+	}
+	assert.Equal(t, []string{"subnet-a", "subnet-b"}, ids)
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+
 	awsutil "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
 )
 
 // AvailabilityZones retrieves a list of availability zones for the given region.
@@ -54,3 +56,17 @@ func fetchAvailabilityZones(client *ec2.EC2, region string) ([]string, error) {
 	}
 	return zones, nil
 }
+
+// PrivateSubnets retrieves the existing private subnets of the platform,
+// keyed by availability zone.  It returns nil if the installer creates the
+// subnets.
+func PrivateSubnets(platform *awstypes.Platform) (map[string]string, error) {
+	if len(platform.Subnets) == 0 {
+		return nil, nil
+	}
+	subnets, err := awsutil.GetSubnets(platform.Region, platform.Subnets)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch subnets: %v", err)
+	}
+	return awsutil.PrivateSubnets(subnets), nil
+}
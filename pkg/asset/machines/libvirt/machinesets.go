@@ -65,6 +65,10 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 					},
 				},
 				Spec: machineapi.MachineSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: pool.Labels,
+					},
+					Taints: pool.Taints,
 					ProviderSpec: machineapi.ProviderSpec{
 						Value: &runtime.RawExtension{Object: provider},
 					},
@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	icaws "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/asset/machines/aws"
 	"github.com/openshift/installer/pkg/asset/machines/libvirt"
 	"github.com/openshift/installer/pkg/asset/machines/openstack"
@@ -67,8 +68,9 @@ func (m *Master) Dependencies() []asset.Asset {
 	}
 }
 
-func awsDefaultMasterMachineType(installconfig *installconfig.InstallConfig) string {
-	region := installconfig.Config.Platform.AWS.Region
+// AWSDefaultMasterMachineType returns the default instance type of the
+// control plane machines in the AWS region.
+func AWSDefaultMasterMachineType(region string) string {
 	instanceClass := awsdefaults.InstanceClass(region)
 	return fmt.Sprintf("%s.xlarge", instanceClass)
 }
@@ -88,10 +90,16 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 	switch ic.Platform.Name() {
 	case awstypes.Name:
 		mpool := defaultAWSMachinePoolPlatform()
-		mpool.InstanceType = awsDefaultMasterMachineType(installconfig)
+		mpool.InstanceType = AWSDefaultMasterMachineType(ic.Platform.AWS.Region)
 		mpool.Set(ic.Platform.AWS.DefaultMachinePlatform)
 		mpool.Set(pool.Platform.AWS)
-		if len(mpool.Zones) == 0 {
+		subnets, err := aws.PrivateSubnets(ic.Platform.AWS)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch subnets")
+		}
+		if len(mpool.Zones) == 0 && len(subnets) > 0 {
+			mpool.Zones = icaws.Zones(subnets)
+		} else if len(mpool.Zones) == 0 {
 			azs, err := aws.AvailabilityZones(ic.Platform.AWS.Region)
 			if err != nil {
 				return errors.Wrap(err, "failed to fetch availability zones")
@@ -99,7 +107,7 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 			mpool.Zones = azs
 		}
 		pool.Platform.AWS = &mpool
-		machines, err = aws.Machines(clusterID.InfraID, ic, pool, string(*rhcosImage), "master", "master-user-data")
+		machines, err = aws.Machines(clusterID.InfraID, ic, pool, subnets, string(*rhcosImage), "master", "master-user-data")
 		if err != nil {
 			return errors.Wrap(err, "failed to create master machine objects")
 		}
@@ -71,6 +71,10 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 					},
 				},
 				Spec: clusterapi.MachineSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: pool.Labels,
+					},
+					Taints: pool.Taints,
 					ProviderSpec: clusterapi.ProviderSpec{
 						Value: &runtime.RawExtension{Object: provider},
 					},
@@ -13,6 +13,7 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	icaws "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	"github.com/openshift/installer/pkg/asset/machines/aws"
 	"github.com/openshift/installer/pkg/asset/machines/libvirt"
 	"github.com/openshift/installer/pkg/asset/machines/openstack"
@@ -43,10 +44,14 @@ func defaultOpenStackMachinePoolPlatform(flavor string) openstacktypes.MachinePo
 	}
 }
 
-// Worker generates the machinesets for `worker` machine pool.
+// Worker generates the machinesets for the compute machine pools, with one
+// machineset per pool per availability zone.  For autoscaled pools, it also
+// generates a machine autoscaler per machineset and the cluster autoscaler.
 type Worker struct {
-	MachineSetRaw     []byte
-	UserDataSecretRaw []byte
+	MachineSetRaw         []byte
+	MachineAutoscalersRaw []byte
+	ClusterAutoscalerRaw  []byte
+	UserDataSecretRaw     []byte
 }
 
 var _ asset.Asset = (*Worker)(nil)
@@ -71,8 +76,9 @@ func (w *Worker) Dependencies() []asset.Asset {
 	}
 }
 
-func awsDefaultWorkerMachineType(installconfig *installconfig.InstallConfig) string {
-	region := installconfig.Config.Platform.AWS.Region
+// AWSDefaultWorkerMachineType returns the default instance type of the
+// compute machines in the AWS region.
+func AWSDefaultWorkerMachineType(region string) string {
 	instanceClass := awsdefaults.InstanceClass(region)
 	return fmt.Sprintf("%s.large", instanceClass)
 }
@@ -93,15 +99,26 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 	}
 
 	machineSets := []runtime.Object{}
+	autoscalers := []runtime.Object{}
 	ic := installconfig.Config
+	var awsSubnets map[string]string
+	if ic.Platform.AWS != nil {
+		awsSubnets, err = aws.PrivateSubnets(ic.Platform.AWS)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch subnets")
+		}
+	}
 	for _, pool := range ic.Compute {
+		var poolSets []runtime.Object
 		switch ic.Platform.Name() {
 		case awstypes.Name:
 			mpool := defaultAWSMachinePoolPlatform()
-			mpool.InstanceType = awsDefaultWorkerMachineType(installconfig)
+			mpool.InstanceType = AWSDefaultWorkerMachineType(ic.Platform.AWS.Region)
 			mpool.Set(ic.Platform.AWS.DefaultMachinePlatform)
 			mpool.Set(pool.Platform.AWS)
-			if len(mpool.Zones) == 0 {
+			if len(mpool.Zones) == 0 && len(awsSubnets) > 0 {
+				mpool.Zones = icaws.Zones(awsSubnets)
+			} else if len(mpool.Zones) == 0 {
 				azs, err := aws.AvailabilityZones(ic.Platform.AWS.Region)
 				if err != nil {
 					return errors.Wrap(err, "failed to fetch availability zones")
@@ -109,12 +126,12 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 				mpool.Zones = azs
 			}
 			pool.Platform.AWS = &mpool
-			sets, err := aws.MachineSets(clusterID.InfraID, ic, &pool, string(*rhcosImage), "worker", "worker-user-data")
+			sets, err := aws.MachineSets(clusterID.InfraID, ic, &pool, awsSubnets, string(*rhcosImage), "worker", "worker-user-data")
 			if err != nil {
 				return errors.Wrap(err, "failed to create worker machine objects")
 			}
 			for _, set := range sets {
-				machineSets = append(machineSets, set)
+				poolSets = append(poolSets, set)
 			}
 		case libvirttypes.Name:
 			mpool := defaultLibvirtMachinePoolPlatform()
@@ -126,7 +143,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 				return errors.Wrap(err, "failed to create worker machine objects")
 			}
 			for _, set := range sets {
-				machineSets = append(machineSets, set)
+				poolSets = append(poolSets, set)
 			}
 		case nonetypes.Name:
 		case openstacktypes.Name:
@@ -140,32 +157,55 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 				return errors.Wrap(err, "failed to create master machine objects")
 			}
 			for _, set := range sets {
-				machineSets = append(machineSets, set)
+				poolSets = append(poolSets, set)
 			}
 		default:
 			return fmt.Errorf("invalid Platform")
 		}
+		machineSets = append(machineSets, poolSets...)
+		if pool.Autoscaling != nil {
+			poolAutoscalers, err := machineAutoscalers(&pool, poolSets)
+			if err != nil {
+				return errors.Wrap(err, "failed to create machine autoscalers")
+			}
+			autoscalers = append(autoscalers, poolAutoscalers...)
+		}
 	}
 
 	if len(machineSets) == 0 {
 		return nil
 	}
+	w.MachineSetRaw, err = listRaw(machineSets)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal")
+	}
+
+	if len(autoscalers) == 0 {
+		return nil
+	}
+	w.MachineAutoscalersRaw, err = listRaw(autoscalers)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal machine autoscalers")
+	}
+	w.ClusterAutoscalerRaw, err = yaml.Marshal(newClusterAutoscaler(ic))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal cluster autoscaler")
+	}
+	return nil
+}
+
+func listRaw(objects []runtime.Object) ([]byte, error) {
 	list := &metav1.List{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
 			Kind:       "List",
 		},
-		Items: make([]runtime.RawExtension, len(machineSets)),
+		Items: make([]runtime.RawExtension, len(objects)),
 	}
-	for i, set := range machineSets {
-		list.Items[i] = runtime.RawExtension{Object: set}
+	for i, object := range objects {
+		list.Items[i] = runtime.RawExtension{Object: object}
 	}
-	raw, err := yaml.Marshal(list)
-	if err != nil {
-		return errors.Wrap(err, "failed to marshal")
-	}
-	w.MachineSetRaw = raw
-	return nil
+	return yaml.Marshal(list)
 }
 
 func applyTemplateData(template *template.Template, templateData interface{}) []byte {
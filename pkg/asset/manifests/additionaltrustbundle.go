@@ -0,0 +1,73 @@
+package manifests
+
+import (
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+var additionalTrustBundleCfgFilename = filepath.Join(manifestDir, "user-ca-bundle-config.yaml")
+
+// AdditionalTrustBundleConfig generates the user-ca-bundle ConfigMap, which
+// carries the additional trust bundle of the install config.
+type AdditionalTrustBundleConfig struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*AdditionalTrustBundleConfig)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*AdditionalTrustBundleConfig) Name() string {
+	return "Additional Trust Bundle Config"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*AdditionalTrustBundleConfig) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the ConfigMap.  Nothing is generated when the install
+// config does not have an additional trust bundle.
+func (a *AdditionalTrustBundleConfig) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	a.FileList = nil
+	if installConfig.Config.AdditionalTrustBundle == "" {
+		return nil
+	}
+
+	config := configMap("openshift-config", "user-ca-bundle", genericData{
+		"ca-bundle.crt": installConfig.Config.AdditionalTrustBundle,
+	})
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal config: %#v", config)
+	}
+
+	a.FileList = []*asset.File{
+		{
+			Filename: additionalTrustBundleCfgFilename,
+			Data:     configData,
+		},
+	}
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (a *AdditionalTrustBundleConfig) Files() []*asset.File {
+	return a.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (a *AdditionalTrustBundleConfig) Load(f asset.FileFetcher) (bool, error) {
+	return false, nil
+}
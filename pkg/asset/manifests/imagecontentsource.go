@@ -0,0 +1,106 @@
+package manifests
+
+import (
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+var imageContentSourcePolicyFilename = filepath.Join(manifestDir, "image-content-source-policy.yaml")
+
+// imageContentSourcePolicy is the operator.openshift.io ImageContentSourcePolicy,
+// which is not vendored.
+type imageContentSourcePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec imageContentSourcePolicySpec `json:"spec"`
+}
+
+type imageContentSourcePolicySpec struct {
+	RepositoryDigestMirrors []repositoryDigestMirrors `json:"repositoryDigestMirrors"`
+}
+
+type repositoryDigestMirrors struct {
+	Source  string   `json:"source"`
+	Mirrors []string `json:"mirrors,omitempty"`
+}
+
+// ImageContentSourcePolicy generates the image-content-source-policy.yaml
+// file, so that the cluster keeps using the mirrors of the install config.
+type ImageContentSourcePolicy struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*ImageContentSourcePolicy)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*ImageContentSourcePolicy) Name() string {
+	return "Image Content Source Policy"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*ImageContentSourcePolicy) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the ImageContentSourcePolicy.  Nothing is generated
+// when the install config does not have image content sources.
+func (p *ImageContentSourcePolicy) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	p.FileList = nil
+	if len(installConfig.Config.ImageContentSources) == 0 {
+		return nil
+	}
+
+	policy := &imageContentSourcePolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "operator.openshift.io/v1alpha1",
+			Kind:       "ImageContentSourcePolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "image-content-sources",
+			// not namespaced
+		},
+	}
+	for _, source := range installConfig.Config.ImageContentSources {
+		policy.Spec.RepositoryDigestMirrors = append(policy.Spec.RepositoryDigestMirrors, repositoryDigestMirrors{
+			Source:  source.Source,
+			Mirrors: source.Mirrors,
+		})
+	}
+
+	policyData, err := yaml.Marshal(policy)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal policy: %#v", policy)
+	}
+
+	p.FileList = []*asset.File{
+		{
+			Filename: imageContentSourcePolicyFilename,
+			Data:     policyData,
+		},
+	}
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (p *ImageContentSourcePolicy) Files() []*asset.File {
+	return p.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (p *ImageContentSourcePolicy) Load(f asset.FileFetcher) (bool, error) {
+	return false, nil
+}
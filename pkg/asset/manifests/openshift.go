@@ -116,11 +116,13 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 		roleCloudCredsSecretReader)
 
 	assetData := map[string][]byte{
-		"99_binding-discovery.yaml":                             []byte(bindingDiscovery.Files()[0].Data),
-		"99_kubeadmin-password-secret.yaml":                     applyTemplateData(kubeadminPasswordSecret.Files()[0].Data, templateData),
-		"99_openshift-cluster-api_cluster.yaml":                 clusterk8sio.Raw,
-		"99_openshift-cluster-api_worker-machineset.yaml":       worker.MachineSetRaw,
-		"99_openshift-cluster-api_worker-user-data-secret.yaml": worker.UserDataSecretRaw,
+		"99_binding-discovery.yaml":                               []byte(bindingDiscovery.Files()[0].Data),
+		"99_kubeadmin-password-secret.yaml":                       applyTemplateData(kubeadminPasswordSecret.Files()[0].Data, templateData),
+		"99_openshift-cluster-api_cluster.yaml":                   clusterk8sio.Raw,
+		"99_openshift-cluster-api_worker-machineset.yaml":         worker.MachineSetRaw,
+		"99_openshift-cluster-api_worker-user-data-secret.yaml":   worker.UserDataSecretRaw,
+		"99_openshift-cluster-api_worker-machineautoscalers.yaml": worker.MachineAutoscalersRaw,
+		"99_openshift-cluster-api_cluster-autoscaler.yaml":        worker.ClusterAutoscalerRaw,
 	}
 
 	switch platform {
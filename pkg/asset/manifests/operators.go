@@ -16,6 +16,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/templates/content/bootkube"
 	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/redact"
 	"github.com/openshift/installer/pkg/types"
 )
 
@@ -59,6 +60,9 @@ func (m *Manifests) Dependencies() []asset.Asset {
 		&DNS{},
 		&Infrastructure{},
 		&Networking{},
+		&Proxy{},
+		&AdditionalTrustBundleConfig{},
+		&ImageContentSourcePolicy{},
 		&tls.RootCA{},
 		&tls.EtcdCA{},
 		&tls.EtcdSignerCertKey{},
@@ -95,8 +99,11 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	dns := &DNS{}
 	network := &Networking{}
 	infra := &Infrastructure{}
+	proxy := &Proxy{}
+	additionalTrustBundleConfig := &AdditionalTrustBundleConfig{}
+	imageContentSourcePolicy := &ImageContentSourcePolicy{}
 	installConfig := &installconfig.InstallConfig{}
-	dependencies.Get(installConfig, ingress, dns, network, infra)
+	dependencies.Get(installConfig, ingress, dns, network, infra, proxy, additionalTrustBundleConfig, imageContentSourcePolicy)
 
 	redactedConfig, err := redactedInstallConfig(*installConfig.Config)
 	if err != nil {
@@ -123,6 +130,9 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	m.FileList = append(m.FileList, dns.Files()...)
 	m.FileList = append(m.FileList, network.Files()...)
 	m.FileList = append(m.FileList, infra.Files()...)
+	m.FileList = append(m.FileList, proxy.Files()...)
+	m.FileList = append(m.FileList, additionalTrustBundleConfig.Files()...)
+	m.FileList = append(m.FileList, imageContentSourcePolicy.Files()...)
 
 	asset.SortFiles(m.FileList)
 
@@ -264,6 +274,12 @@ func (m *Manifests) Load(f asset.FileFetcher) (bool, error) {
 
 func redactedInstallConfig(config types.InstallConfig) ([]byte, error) {
 	config.PullSecret = ""
+	if config.Proxy != nil {
+		proxy := *config.Proxy
+		proxy.HTTPProxy = redact.URL(proxy.HTTPProxy)
+		proxy.HTTPSProxy = redact.URL(proxy.HTTPSProxy)
+		config.Proxy = &proxy
+	}
 	return yaml.Marshal(config)
 }
 
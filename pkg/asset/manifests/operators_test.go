@@ -0,0 +1,39 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestRedactedInstallConfig(t *testing.T) {
+	proxy := &types.Proxy{
+		HTTPProxy:  "http://user:pw@proxy.example.com:3128",
+		HTTPSProxy: "https://proxy.example.com:3129",
+		NoProxy:    ".example.com",
+	}
+	config := types.InstallConfig{
+		BaseDomain: "example.com",
+		PullSecret: `{"auths":{"example.com":{"auth":"authorization value"}}}`,
+		Proxy:      proxy,
+	}
+
+	data, err := redactedInstallConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redacted := &types.InstallConfig{}
+	if err := yaml.Unmarshal(data, redacted); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "", redacted.PullSecret)
+	assert.Equal(t, &types.Proxy{
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "https://proxy.example.com:3129",
+		NoProxy:    ".example.com",
+	}, redacted.Proxy)
+	assert.Equal(t, "http://user:pw@proxy.example.com:3128", proxy.HTTPProxy, "the install-config was modified")
+}
@@ -0,0 +1,88 @@
+package manifests
+
+import (
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var proxyCfgFilename = filepath.Join(manifestDir, "cluster-proxy-01-config.yaml")
+
+// Proxy generates the cluster-proxy-*.yaml files.
+type Proxy struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Proxy)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*Proxy) Name() string {
+	return "Proxy Config"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*Proxy) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the Proxy config.  Nothing is generated when the
+// install config does not configure a proxy.
+func (p *Proxy) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	p.FileList = nil
+	proxy := installConfig.Config.Proxy
+	if proxy == nil {
+		return nil
+	}
+
+	config := &configv1.Proxy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configv1.SchemeGroupVersion.String(),
+			Kind:       "Proxy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+		Spec: configv1.ProxySpec{
+			HTTPProxy:  proxy.HTTPProxy,
+			HTTPSProxy: proxy.HTTPSProxy,
+			NoProxy:    proxy.NoProxy,
+		},
+	}
+
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal config: %#v", config)
+	}
+
+	p.FileList = []*asset.File{
+		{
+			Filename: proxyCfgFilename,
+			Data:     configData,
+		},
+	}
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (p *Proxy) Files() []*asset.File {
+	return p.FileList
+}
+
+// Load returns false since this asset is not written to disk by the installer.
+func (p *Proxy) Load(f asset.FileFetcher) (bool, error) {
+	return false, nil
+}
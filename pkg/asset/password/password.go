@@ -14,7 +14,7 @@ type KubeadminPassword struct {
 	PasswordHash []byte
 }
 
-var _ asset.Asset = (*KubeadminPassword)(nil)
+var _ asset.SecretAsset = (*KubeadminPassword)(nil)
 
 // Dependencies returns no dependencies.
 func (a *KubeadminPassword) Dependencies() []asset.Asset {
@@ -77,3 +77,8 @@ func (a *KubeadminPassword) generateRandomPasswordHash(length int) error {
 func (a *KubeadminPassword) Name() string {
 	return "Kubeadmin Password"
 }
+
+// Secrets returns the password.
+func (a *KubeadminPassword) Secrets() []string {
+	return []string{a.Password}
+}
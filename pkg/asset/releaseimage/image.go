@@ -0,0 +1,86 @@
+// Package releaseimage contains the asset for the release image installed
+// on the cluster.
+package releaseimage
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/validate"
+)
+
+var (
+	// defaultImage is the release image used unless it is overridden.
+	// Release builds set it to a digest reference with hack/build.sh.
+	defaultImage = "registry.svc.ci.openshift.org/openshift/origin-release:v4.0"
+
+	// AllowTags allows overriding the release image with a reference by
+	// tag.  By default, overrides must reference the image by digest, so
+	// that the installed release is reproducible.
+	AllowTags bool
+)
+
+// Image is the release image installed on the cluster.  It is recorded in
+// the state file, so that the same image is used for all of the assets
+// generated in the install directory.
+type Image struct {
+	// PullSpec is the pull spec of the release image.
+	PullSpec string
+}
+
+var _ asset.Asset = (*Image)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (a *Image) Name() string {
+	return "Release Image"
+}
+
+// Dependencies returns no dependencies.
+func (a *Image) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate chooses the release image, which is the default image unless it
+// is overridden with OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE.
+func (a *Image) Generate(asset.Parents) error {
+	pullSpec := Override()
+	if pullSpec == "" {
+		if err := validate.ImageDigestReference(defaultImage); err != nil {
+			logrus.Warnf("The default release image %s is not referenced by digest, so the installed release is not pinned", defaultImage)
+		}
+		a.PullSpec = defaultImage
+		return nil
+	}
+
+	logrus.Warn("Found override for ReleaseImage. Please be warned, this is not advised")
+	if err := validatePullSpec(pullSpec, AllowTags); err != nil {
+		return errors.Wrap(err, "invalid OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE")
+	}
+	a.PullSpec = pullSpec
+	return nil
+}
+
+// Default returns the release image used unless it is overridden.
+func Default() string {
+	return defaultImage
+}
+
+// Override returns the release image set with
+// OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE, or an empty string if it is not
+// overridden.
+func Override() string {
+	return os.Getenv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE")
+}
+
+func validatePullSpec(pullSpec string, allowTags bool) error {
+	if allowTags {
+		return validate.ImageReference(pullSpec)
+	}
+	if err := validate.ImageDigestReference(pullSpec); err != nil {
+		return errors.Wrap(err, "use --allow-release-image-tag to allow references by tag")
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package releaseimage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageGenerate(t *testing.T) {
+	const digestPullSpec = "quay.io/openshift-release-dev/ocp-release@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	cases := []struct {
+		name          string
+		override      string
+		allowTags     bool
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "default",
+			expected: defaultImage,
+		},
+		{
+			name:     "digest override",
+			override: digestPullSpec,
+			expected: digestPullSpec,
+		},
+		{
+			name:          "tag override",
+			override:      "quay.io/openshift-release-dev/ocp-release:4.0.0",
+			expectedError: `^invalid OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE: use --allow-release-image-tag to allow references by tag: "quay\.io/openshift-release-dev/ocp-release:4\.0\.0" must reference the image by digest, like <repository>@sha256:<digest>$`,
+		},
+		{
+			name:      "allowed tag override",
+			override:  "quay.io/openshift-release-dev/ocp-release:4.0.0",
+			allowTags: true,
+			expected:  "quay.io/openshift-release-dev/ocp-release:4.0.0",
+		},
+		{
+			name:          "invalid override",
+			override:      "not an image",
+			allowTags:     true,
+			expectedError: `^invalid OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE: "not an image" must reference the image by tag or digest$`,
+		},
+	}
+	defer func() { AllowTags = false }()
+	defer os.Unsetenv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE")
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv("OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE", tc.override)
+			AllowTags = tc.allowTags
+
+			image := &Image{}
+			err := image.Generate(nil)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, image.PullSpec)
+			} else {
+				assert.Regexp(t, tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package releaseimage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/openpgp"
+)
+
+const (
+	// maxSignatures is the most signatures fetched for an image.
+	maxSignatures = 16
+
+	// maxSignatureSize is the largest signature that is read.
+	maxSignatureSize = 64 * 1024
+
+	// signatureType is the type of the signatures of container images.
+	signatureType = "atomic container signature"
+)
+
+// errSignatureNotFound is returned by the store when there is no signature
+// with the requested name.
+var errSignatureNotFound = errors.New("signature not found")
+
+// signature is the content of a container image signature.
+type signature struct {
+	Critical struct {
+		Type  string `json:"type"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+	} `json:"critical"`
+}
+
+// verifier checks the signatures of release images.
+type verifier struct {
+	store   *url.URL
+	keyring openpgp.EntityList
+	client  *http.Client
+}
+
+// Verify checks that the release image is signed by one of the keys of the
+// keyring, if it is set.  The keyring is the path of a GPG keyring, armored
+// or binary.  The signature store is the URL of the store serving the
+// signatures of release images, either http(s):// or file://.  The
+// signatures of an image are at <store>/<algorithm>=<digest>/signature-<n>,
+// with n counting from 1.
+func Verify(pullSpec, keyringPath, signatureStore string) error {
+	if keyringPath == "" {
+		if signatureStore != "" {
+			return errors.New("a keyring is required to verify release image signatures")
+		}
+		return nil
+	}
+	if signatureStore == "" {
+		return errors.New("a signature store is required to verify release image signatures")
+	}
+
+	keyring, err := loadKeyring(keyringPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load keyring %s", keyringPath)
+	}
+	store, err := url.Parse(signatureStore)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature store")
+	}
+	v := &verifier{
+		store:   store,
+		keyring: keyring,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+	if err := v.verify(pullSpec); err != nil {
+		return err
+	}
+	logrus.Infof("Verified the signature of release image %s", pullSpec)
+	return nil
+}
+
+// verify checks that at least one of the signatures of the image in the
+// store is valid.
+func (v *verifier) verify(pullSpec string) error {
+	i := strings.Index(pullSpec, "@")
+	if i < 0 {
+		return errors.Errorf("release image %s must be referenced by digest to verify its signature", pullSpec)
+	}
+	digest := pullSpec[i+1:]
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 {
+		return errors.Errorf("invalid digest %q", digest)
+	}
+
+	var failures []string
+	for n := 1; n <= maxSignatures; n++ {
+		name := fmt.Sprintf("%s=%s/signature-%d", parts[0], parts[1], n)
+		data, err := v.fetch(name)
+		if err == errSignatureNotFound {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch signature %s", name)
+		}
+		err = v.check(data, digest)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+	}
+	if len(failures) == 0 {
+		return errors.Errorf("no signatures found for %s in %s", digest, v.store)
+	}
+	return errors.Errorf("no valid signature found for %s:\n%s", digest, strings.Join(failures, "\n"))
+}
+
+// fetch returns the signature with the given name from the store.
+func (v *verifier) fetch(name string) ([]byte, error) {
+	switch v.store.Scheme {
+	case "file":
+		data, err := ioutil.ReadFile(filepath.Join(v.store.Path, filepath.FromSlash(name)))
+		if os.IsNotExist(err) {
+			return nil, errSignatureNotFound
+		}
+		return data, err
+	case "http", "https":
+		u := *v.store
+		u.Path = path.Join(u.Path, name)
+		resp, err := v.client.Get(u.String())
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return ioutil.ReadAll(io.LimitReader(resp.Body, maxSignatureSize))
+		case http.StatusNotFound:
+			return nil, errSignatureNotFound
+		default:
+			return nil, errors.Errorf("unexpected status %s", resp.Status)
+		}
+	default:
+		return nil, errors.Errorf("unsupported signature store scheme %q, must be file, http or https", v.store.Scheme)
+	}
+}
+
+// check checks that the signature is signed by a key of the keyring and
+// signs the given digest.
+func (v *verifier) check(data []byte, digest string) error {
+	md, err := openpgp.ReadMessage(bytes.NewReader(data), v.keyring, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the signature")
+	}
+	if !md.IsSigned {
+		return errors.New("not signed")
+	}
+	if md.SignedBy == nil {
+		return errors.Errorf("signed by unknown key %X", md.SignedByKeyId)
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(md.UnverifiedBody, maxSignatureSize))
+	if err != nil {
+		return errors.Wrap(err, "failed to read the signed content")
+	}
+	if md.SignatureError != nil {
+		return errors.Wrap(md.SignatureError, "invalid signature")
+	}
+
+	var sig signature
+	if err := json.Unmarshal(content, &sig); err != nil {
+		return errors.Wrap(err, "failed to parse the signed content")
+	}
+	if sig.Critical.Type != signatureType {
+		return errors.Errorf("unexpected signature type %q", sig.Critical.Type)
+	}
+	if sig.Critical.Image.DockerManifestDigest != digest {
+		return errors.Errorf("signs digest %s instead", sig.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
+
+// loadKeyring reads an armored or binary keyring.
+func loadKeyring(path string) (openpgp.EntityList, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		keyring, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(keyring) == 0 {
+		return nil, errors.New("no keys found")
+	}
+	return keyring, nil
+}
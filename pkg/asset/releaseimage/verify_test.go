@@ -0,0 +1,146 @@
+package releaseimage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/openpgp"
+)
+
+const (
+	testDigest      = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	testOtherDigest = "sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+	testPullSpec    = "quay.io/openshift-release-dev/ocp-release@" + testDigest
+)
+
+func signImage(t *testing.T, signer *openpgp.Entity, digest string) []byte {
+	// NewEntity does not set any preferred hashes, and Sign falls back to
+	// RIPEMD-160, which is not compiled in.
+	for _, identity := range signer.Identities {
+		identity.SelfSignature.PreferredHash = []uint8{8} // SHA-256
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := openpgp.Sign(buf, signer, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fmt.Fprintf(w, `{"critical":{"type":%q,"image":{"docker-manifest-digest":%q},"identity":{"docker-reference":"quay.io/openshift-release-dev/ocp-release"}}}`, signatureType, digest); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestVerifierVerify(t *testing.T) {
+	trusted, err := openpgp.NewEntity("trusted", "", "trusted@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrusted, err := openpgp.NewEntity("untrusted", "", "untrusted@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name          string
+		pullSpec      string
+		signatures    [][]byte
+		expectedError string
+	}{
+		{
+			name:       "valid",
+			pullSpec:   testPullSpec,
+			signatures: [][]byte{signImage(t, trusted, testDigest)},
+		},
+		{
+			name:     "valid second signature",
+			pullSpec: testPullSpec,
+			signatures: [][]byte{
+				signImage(t, untrusted, testDigest),
+				signImage(t, trusted, testDigest),
+			},
+		},
+		{
+			name:          "no signatures",
+			pullSpec:      testPullSpec,
+			expectedError: `^no signatures found for sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef in `,
+		},
+		{
+			name:          "untrusted key",
+			pullSpec:      testPullSpec,
+			signatures:    [][]byte{signImage(t, untrusted, testDigest)},
+			expectedError: `(?s)^no valid signature found for sha256:[0-9a-f]*:\nsha256=[0-9a-f]*/signature-1: signed by unknown key [0-9A-F]*$`,
+		},
+		{
+			name:          "other digest",
+			pullSpec:      testPullSpec,
+			signatures:    [][]byte{signImage(t, trusted, testOtherDigest)},
+			expectedError: `(?s)/signature-1: signs digest sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210 instead$`,
+		},
+		{
+			name:          "corrupt signature",
+			pullSpec:      testPullSpec,
+			signatures:    [][]byte{[]byte("not a signature")},
+			expectedError: `(?s)/signature-1: failed to read the signature: `,
+		},
+		{
+			name:          "tag",
+			pullSpec:      "quay.io/openshift-release-dev/ocp-release:4.0.0",
+			expectedError: `^release image quay\.io/openshift-release-dev/ocp-release:4\.0\.0 must be referenced by digest to verify its signature$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "openshift-install-signatures-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			sigDir := filepath.Join(dir, "sha256=0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+			if err := os.MkdirAll(sigDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for i, sig := range tc.signatures {
+				if err := ioutil.WriteFile(filepath.Join(sigDir, fmt.Sprintf("signature-%d", i+1)), sig, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+			defer server.Close()
+			httpStore, err := url.Parse(server.URL + "/")
+			if err != nil {
+				t.Fatal(err)
+			}
+			stores := map[string]*url.URL{
+				"file": {Scheme: "file", Path: dir},
+				"http": httpStore,
+			}
+
+			for scheme, store := range stores {
+				v := &verifier{
+					store:   store,
+					keyring: openpgp.EntityList{trusted},
+					client:  server.Client(),
+				}
+				err := v.verify(tc.pullSpec)
+				if tc.expectedError == "" {
+					assert.NoError(t, err, scheme)
+				} else {
+					assert.Regexp(t, tc.expectedError, err, scheme)
+				}
+			}
+		})
+	}
+}
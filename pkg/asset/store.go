@@ -6,6 +6,16 @@ type Store interface {
 	// dependencies if necessary.
 	Fetch(Asset) error
 
+	// Load retrieves the given asset if it is present in the target
+	// directory or the state file.  Unlike Fetch, it never generates the
+	// asset, and returns false if the asset is not present.
+	Load(Asset) (bool, error)
+
+	// Peek retrieves the state of the given asset like Fetch, generating
+	// it and its dependencies if necessary, but neither saves the state
+	// file nor consumes the assets in the target directory.
+	Peek(Asset) error
+
 	// Destroy removes the asset from all its internal state and also from
 	// disk if possible.
 	Destroy(Asset) error
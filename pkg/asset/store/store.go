@@ -11,6 +11,9 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/events"
+	"github.com/openshift/installer/pkg/metrics"
+	"github.com/openshift/installer/pkg/redact"
 )
 
 const (
@@ -76,6 +79,7 @@ func (s *storeImpl) Fetch(a asset.Asset) error {
 	if err := s.fetch(a, ""); err != nil {
 		return err
 	}
+	events.Emit(events.AssetFetched, map[string]string{"asset": a.Name()})
 	if err := s.saveStateFile(); err != nil {
 		return errors.Wrap(err, "failed to save state")
 	}
@@ -85,6 +89,28 @@ func (s *storeImpl) Fetch(a asset.Asset) error {
 	return nil
 }
 
+// Load retrieves the given asset if it is present in the target directory
+// or the state file.  Unlike Fetch, it never generates the asset, and
+// returns false if the asset is not present.
+func (s *storeImpl) Load(a asset.Asset) (bool, error) {
+	state, err := s.load(a, "")
+	if err != nil {
+		return false, err
+	}
+	if state.source == unfetched {
+		return false, nil
+	}
+	reflect.ValueOf(a).Elem().Set(reflect.ValueOf(state.asset).Elem())
+	return true, nil
+}
+
+// Peek retrieves the state of the given asset like Fetch, but the assets
+// that had to be generated are discarded instead of being saved to the
+// state file, and the assets in the target directory are left in place.
+func (s *storeImpl) Peek(a asset.Asset) error {
+	return s.fetch(a, "")
+}
+
 // Destroy removes the asset from all its internal state and also from
 // disk if possible.
 func (s *storeImpl) Destroy(a asset.Asset) error {
@@ -192,8 +218,9 @@ func (s *storeImpl) saveStateFile() error {
 // fetch populates the given asset, generating it and its dependencies if
 // necessary, and returns whether or not the asset had to be regenerated and
 // any errors.
-func (s *storeImpl) fetch(a asset.Asset, indent string) error {
+func (s *storeImpl) fetch(a asset.Asset, indent string) (err error) {
 	logrus.Debugf("%sFetching %q...", indent, a.Name())
+	stopTimer := metrics.Start(metrics.AssetFetch, a.Name())
 
 	assetState, ok := s.assets[reflect.TypeOf(a)]
 	if !ok {
@@ -213,6 +240,8 @@ func (s *storeImpl) fetch(a asset.Asset, indent string) error {
 		return nil
 	}
 
+	defer stopTimer(&err)
+
 	// Re-generate the asset
 	dependencies := a.Dependencies()
 	parents := make(asset.Parents, len(dependencies))
@@ -223,9 +252,16 @@ func (s *storeImpl) fetch(a asset.Asset, indent string) error {
 		parents.Add(d)
 	}
 	logrus.Debugf("%sGenerating %q...", indent, a.Name())
-	if err := a.Generate(parents); err != nil {
+	stopGenerateTimer := metrics.Start(metrics.AssetGenerate, a.Name())
+	err = a.Generate(parents)
+	stopGenerateTimer(&err)
+	// Register the secrets even if the generation failed, because the
+	// error may quote them.
+	addSecrets(a)
+	if err != nil {
 		return errors.Wrapf(err, "failed to generate asset %q", a.Name())
 	}
+	events.Emit(events.AssetGenerated, map[string]string{"asset": a.Name()})
 	assetState.asset = a
 	assetState.source = generatedSource
 	return nil
@@ -261,6 +297,9 @@ func (s *storeImpl) load(a asset.Asset, indent string) (*assetState, error) {
 		onDiskAsset = reflect.New(reflect.TypeOf(a).Elem()).Interface().(asset.WritableAsset)
 		var err error
 		foundOnDisk, err = onDiskAsset.Load(s.fileFetcher)
+		// Register the secrets even if the asset is invalid or is
+		// discarded, because the error or later messages may quote them.
+		addSecrets(onDiskAsset)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to load asset %q", a.Name())
 		}
@@ -281,6 +320,7 @@ func (s *storeImpl) load(a asset.Asset, indent string) (*assetState, error) {
 			if err := s.loadAssetFromState(stateFileAsset); err != nil {
 				return nil, errors.Wrapf(err, "failed to load asset %q from state file", a.Name())
 			}
+			addSecrets(stateFileAsset)
 		}
 
 		if foundOnDisk && foundInStateFile {
@@ -312,11 +352,13 @@ func (s *storeImpl) load(a asset.Asset, indent string) (*assetState, error) {
 		logrus.Debugf("%sUsing %q loaded from target directory", indent, a.Name())
 		assetToStore = onDiskAsset
 		source = onDiskSource
+		events.Emit(events.AssetLoaded, map[string]string{"asset": a.Name(), "source": "target directory"})
 	// The asset is in the state file. The asset is sourced from state file.
 	case foundInStateFile:
 		logrus.Debugf("%sUsing %q loaded from state file", indent, a.Name())
 		assetToStore = stateFileAsset
 		source = stateFileSource
+		events.Emit(events.AssetLoaded, map[string]string{"asset": a.Name(), "source": "state file"})
 	// There is no existing source for the asset. The asset will be generated.
 	default:
 		source = unfetched
@@ -352,6 +394,16 @@ func (s *storeImpl) purge(excluded asset.WritableAsset) error {
 	return nil
 }
 
+// addSecrets registers the secrets of the asset, if it holds any, so that
+// they are masked in logs.
+func addSecrets(a asset.Asset) {
+	if sa, ok := a.(asset.SecretAsset); ok {
+		for _, secret := range sa.Secrets() {
+			redact.AddSecret(secret)
+		}
+	}
+}
+
 func increaseIndent(indent string) string {
 	return indent + "  "
 }
@@ -1,14 +1,18 @@
 package store
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/redact"
 )
 
 var (
@@ -387,3 +391,203 @@ func TestStoreFetchOnDiskAssets(t *testing.T) {
 		})
 	}
 }
+
+// TestStoreLoad tests the Load method of StoreImpl.
+func TestStoreLoad(t *testing.T) {
+	cases := []struct {
+		name          string
+		assets        map[string][]string
+		onDiskAssets  []string
+		target        string
+		expectedFound bool
+	}{
+		{
+			name: "not present",
+			assets: map[string][]string{
+				"a": {"b"},
+				"b": {},
+			},
+			target:        "a",
+			expectedFound: false,
+		},
+		{
+			name: "on disk",
+			assets: map[string][]string{
+				"a": {"b"},
+				"b": {},
+			},
+			onDiskAssets:  []string{"a"},
+			target:        "a",
+			expectedFound: true,
+		},
+		{
+			name: "dirty dependency",
+			assets: map[string][]string{
+				"a": {"b"},
+				"b": {},
+			},
+			onDiskAssets:  []string{"a", "b"},
+			target:        "a",
+			expectedFound: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearAssetBehaviors()
+			store := &storeImpl{
+				assets: map[reflect.Type]*assetState{},
+			}
+			assets := make(map[string]asset.Asset, len(tc.assets))
+			for name := range tc.assets {
+				assets[name] = newTestStoreAsset(name)
+			}
+			for name, deps := range tc.assets {
+				dependenciesOfAsset := make([]asset.Asset, len(deps))
+				for i, d := range deps {
+					dependenciesOfAsset[i] = assets[d]
+				}
+				dependencies[reflect.TypeOf(assets[name])] = dependenciesOfAsset
+			}
+			for _, name := range tc.onDiskAssets {
+				onDiskAssets[reflect.TypeOf(assets[name])] = true
+			}
+			found, err := store.Load(assets[tc.target])
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Empty(t, generationLog, "assets must not be generated")
+		})
+	}
+}
+
+// TestStorePeek tests that the Peek method of StoreImpl generates assets
+// without saving the state file or consuming the on-disk assets.
+func TestStorePeek(t *testing.T) {
+	clearAssetBehaviors()
+	dir, err := ioutil.TempDir("", "TestStorePeek")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "b"), []byte("b"), 0644); err != nil {
+		t.Fatalf("failed to write on-disk asset: %v", err)
+	}
+
+	store := &storeImpl{
+		directory: dir,
+		assets:    map[reflect.Type]*assetState{},
+	}
+	a, b, c := &testStoreAssetA{}, &testStoreAssetB{}, &testStoreAssetC{}
+	dependencies[reflect.TypeOf(a)] = []asset.Asset{b, c}
+	onDiskAssets[reflect.TypeOf(b)] = true
+
+	err = store.Peek(a)
+	assert.NoError(t, err, "unexpected error")
+	assert.EqualValues(t, []string{"c", "a"}, generationLog)
+	assert.FileExists(t, filepath.Join(dir, "b"), "on-disk asset must not be consumed")
+	_, err = os.Stat(filepath.Join(dir, stateFileName))
+	assert.True(t, os.IsNotExist(err), "state file must not be saved")
+}
+
+type testStoreSecretAsset struct {
+	Secret string
+}
+
+func (a *testStoreSecretAsset) Name() string {
+	return "secret"
+}
+
+func (a *testStoreSecretAsset) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+func (a *testStoreSecretAsset) Generate(asset.Parents) error {
+	a.Secret = "generated-secret-value"
+	return nil
+}
+
+func (a *testStoreSecretAsset) Secrets() []string {
+	return []string{a.Secret}
+}
+
+// TestStoreAddsSecrets tests that the secrets of assets are registered for
+// redaction whether the assets are generated or loaded from the state file.
+func TestStoreAddsSecrets(t *testing.T) {
+	cases := []struct {
+		name   string
+		state  map[string]json.RawMessage
+		secret string
+	}{
+		{
+			name:   "generated",
+			secret: "generated-secret-value",
+		},
+		{
+			name: "state file",
+			state: map[string]json.RawMessage{
+				"*store.testStoreSecretAsset": json.RawMessage(`{"Secret": "state-file-secret-value"}`),
+			},
+			secret: "state-file-secret-value",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "TestStoreAddsSecrets")
+			if err != nil {
+				t.Fatalf("failed to create temporary directory: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			store := &storeImpl{
+				directory:       dir,
+				assets:          map[reflect.Type]*assetState{},
+				stateFileAssets: tc.state,
+			}
+
+			a := &testStoreSecretAsset{}
+			err = store.Fetch(a)
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.secret, a.Secret)
+			assert.Equal(t, "secret: "+redact.Mask, redact.String("secret: "+tc.secret))
+		})
+	}
+}
+
+type testStoreInvalidSecretAsset struct {
+	testStoreSecretAsset
+}
+
+func (a *testStoreInvalidSecretAsset) Files() []*asset.File {
+	return []*asset.File{}
+}
+
+func (a *testStoreInvalidSecretAsset) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName("secret")
+	if err != nil {
+		return false, err
+	}
+	a.Secret = string(file.Data)
+	return false, errors.Errorf("invalid secret %q", a.Secret)
+}
+
+// TestStoreAddsSecretsOfInvalidAssets tests that the secrets of assets are
+// registered for redaction even if the assets fail to load, because the
+// errors may quote them.
+func TestStoreAddsSecretsOfInvalidAssets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestStoreAddsSecretsOfInvalidAssets")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "secret"), []byte("invalid-secret-value"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	store, err := newStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Fetch(&testStoreInvalidSecretAsset{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	assert.NotContains(t, redact.String(err.Error()), "invalid-secret-value")
+}
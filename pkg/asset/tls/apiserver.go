@@ -34,7 +34,7 @@ func (a *APIServerCertKey) Generate(dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	dependencies.Get(kubeCA, installConfig)
 
-	apiServerAddress, err := cidrhost(installConfig.Config.Networking.ServiceNetwork[0].IPNet, 1)
+	apiServerAddresses, err := serviceNetworkHosts(installConfig.Config, 1)
 	if err != nil {
 		return errors.Wrap(err, "failed to get API Server address from InstallConfig")
 	}
@@ -51,7 +51,7 @@ func (a *APIServerCertKey) Generate(dependencies asset.Parents) error {
 			"kubernetes.default.svc.cluster.local",
 			"localhost",
 		},
-		IPAddresses: []net.IP{net.ParseIP(apiServerAddress), net.ParseIP("127.0.0.1")},
+		IPAddresses: append(apiServerAddresses, net.ParseIP("127.0.0.1"), net.ParseIP("::1")),
 	}
 
 	return a.SignedCertKey.Generate(cfg, kubeCA, "apiserver", AppendParent)
@@ -331,7 +331,7 @@ func (a *KubeAPIServerServiceNetworkServerCertKey) Generate(dependencies asset.P
 	ca := &KubeAPIServerServiceNetworkSignerCertKey{}
 	installConfig := &installconfig.InstallConfig{}
 	dependencies.Get(ca, installConfig)
-	serviceAddress, err := cidrhost(installConfig.Config.Networking.ServiceNetwork[0].IPNet, 1)
+	serviceAddresses, err := serviceNetworkHosts(installConfig.Config, 1)
 	if err != nil {
 		return errors.Wrap(err, "failed to get service address for kube-apiserver from InstallConfig")
 	}
@@ -346,7 +346,7 @@ func (a *KubeAPIServerServiceNetworkServerCertKey) Generate(dependencies asset.P
 			"kubernetes.default.svc",
 			"kubernetes.default.svc.cluster.local",
 		},
-		IPAddresses: []net.IP{net.ParseIP(serviceAddress)},
+		IPAddresses: serviceAddresses,
 	}
 
 	return a.SignedCertKey.Generate(cfg, ca, "kube-apiserver-service-network-server", AppendParent)
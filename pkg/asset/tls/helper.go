@@ -30,3 +30,18 @@ func cidrhost(network net.IPNet, hostNum int) (string, error) {
 
 	return ip.String(), nil
 }
+
+// serviceNetworkHosts returns the address of the given host in each of the
+// service networks, e.g. the IPv4 and IPv6 addresses of the kubernetes
+// service of a dual-stack cluster.
+func serviceNetworkHosts(cfg *types.InstallConfig, hostNum int) ([]net.IP, error) {
+	var ips []net.IP
+	for _, network := range cfg.Networking.ServiceNetwork {
+		host, err := cidrhost(network.IPNet, hostNum)
+		if err != nil {
+			return nil, err
+		}
+		ips = append(ips, net.ParseIP(host))
+	}
+	return ips, nil
+}
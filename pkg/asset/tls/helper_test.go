@@ -0,0 +1,49 @@
+package tls
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestServiceNetworkHosts(t *testing.T) {
+	cases := []struct {
+		name           string
+		serviceNetwork []ipnet.IPNet
+		expected       []net.IP
+	}{
+		{
+			name:           "IPv4",
+			serviceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16")},
+			expected:       []net.IP{net.ParseIP("172.30.0.1")},
+		},
+		{
+			name:           "IPv6",
+			serviceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("fd02::/112")},
+			expected:       []net.IP{net.ParseIP("fd02::1")},
+		},
+		{
+			name: "dual-stack",
+			serviceNetwork: []ipnet.IPNet{
+				*ipnet.MustParseCIDR("172.30.0.0/16"),
+				*ipnet.MustParseCIDR("fd02::/112"),
+			},
+			expected: []net.IP{net.ParseIP("172.30.0.1"), net.ParseIP("fd02::1")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &types.InstallConfig{
+				Networking: &types.Networking{ServiceNetwork: tc.serviceNetwork},
+			}
+			actual, err := serviceNetworkHosts(cfg, 1)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, actual)
+			}
+		})
+	}
+}
@@ -206,6 +206,69 @@ func (o *ClusterUninstaller) Run() error {
 		o.Logger.Debug(err)
 		return err
 	}
+
+	o.Logger.Debug("search for shared resources")
+	if err := o.removeSharedTags(resourcegroupstaggingapi.New(awsSession)); err != nil {
+		o.Logger.Debug(err)
+		return err
+	}
+	return nil
+}
+
+// removeSharedTags removes the cluster's tags from resources that the cluster
+// shares but does not own, such as the existing subnets of a VPC that the
+// cluster was installed into.  The resources themselves are left alone.  A
+// resource is shared with the cluster if it has the "shared" value for a
+// kubernetes.io/cluster/ tag key that the filters match with "owned".
+func (o *ClusterUninstaller) removeSharedTags(client *resourcegroupstaggingapi.ResourceGroupsTaggingAPI) error {
+	for _, filter := range o.Filters {
+		for key, value := range filter {
+			if !strings.HasPrefix(key, "kubernetes.io/cluster/") || value != "owned" {
+				continue
+			}
+
+			var arns []*string
+			err := client.GetResourcesPages(
+				&resourcegroupstaggingapi.GetResourcesInput{
+					TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+						Key:    aws.String(key),
+						Values: []*string{aws.String("shared")},
+					}},
+				},
+				func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+					for _, resource := range results.ResourceTagMappingList {
+						arns = append(arns, resource.ResourceARN)
+					}
+					return !lastPage
+				},
+			)
+			if err != nil {
+				return errors.Wrap(err, "get shared resources")
+			}
+
+			// UntagResources accepts at most 20 ARNs at a time.
+			for len(arns) > 0 {
+				batch := arns
+				if len(batch) > 20 {
+					batch = batch[:20]
+				}
+				arns = arns[len(batch):]
+				response, err := client.UntagResources(&resourcegroupstaggingapi.UntagResourcesInput{
+					ResourceARNList: batch,
+					TagKeys:         []*string{aws.String(key)},
+				})
+				if err != nil {
+					return errors.Wrapf(err, "removing %s tag", key)
+				}
+				for _, arn := range batch {
+					if failure, ok := response.FailedResourcesMap[*arn]; ok {
+						return errors.Errorf("removing %s tag from %s: %s", key, *arn, aws.StringValue(failure.ErrorMessage))
+					}
+					o.Logger.WithField("arn", *arn).Infof("Removed %s tag", key)
+				}
+			}
+		}
+	}
 	return nil
 }
 
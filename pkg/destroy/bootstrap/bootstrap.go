@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/metrics"
 	"github.com/openshift/installer/pkg/terraform"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/pkg/errors"
@@ -16,6 +17,8 @@ import (
 
 // Destroy uses Terraform to remove bootstrap resources.
 func Destroy(dir string) (err error) {
+	defer metrics.Start(metrics.BootstrapDestroy, "")(&err)
+
 	metadata, err := cluster.LoadMetadata(dir)
 	if err != nil {
 		return err
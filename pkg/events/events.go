@@ -0,0 +1,110 @@
+// Package events writes machine-readable events describing the progress
+// of the installer, for tools that wrap it.
+//
+// Events are written as JSON Lines: one JSON object per line with the
+// following fields:
+//
+//   timestamp  the time of the event, in RFC 3339 format
+//   type       one of the Type values below
+//   data       an object of string properties specific to the type, which
+//              may be omitted if there are none
+//
+// New types and properties may be added, but existing ones are not changed
+// or removed.
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Type identifies the kind of an event.
+type Type string
+
+const (
+	// AssetLoaded is emitted when an asset is loaded from the target
+	// directory or the state file instead of being generated.  The asset
+	// property is the name of the asset, and source is either "target
+	// directory" or "state file".
+	AssetLoaded Type = "asset-loaded"
+
+	// AssetGenerated is emitted when an asset is generated.  The asset
+	// property is the name of the asset.
+	AssetGenerated Type = "asset-generated"
+
+	// AssetFetched is emitted when an asset requested by a command, and
+	// all of its dependencies, are ready.  The asset property is the name
+	// of the asset.
+	AssetFetched Type = "asset-fetched"
+
+	// TerraformResourceCreated is emitted when Terraform has created a
+	// resource.  The address property is the Terraform address of the
+	// resource, id is its ID, and duration is how long it took to create.
+	TerraformResourceCreated Type = "terraform-resource-created"
+
+	// APIUp is emitted when the Kubernetes API responds.  The host
+	// property is the URL of the API and version is its version.
+	APIUp Type = "api-up"
+
+	// BootstrapComplete is emitted when the bootstrap node has handed the
+	// control plane over to the masters.
+	BootstrapComplete Type = "bootstrap-complete"
+
+	// ClusterOperatorsProgressing is emitted when the cluster version
+	// operator reports progress while the cluster initializes.  The
+	// message property is the message of the operator.
+	ClusterOperatorsProgressing Type = "cluster-operators-progressing"
+
+	// InstallComplete is emitted when the cluster is ready.  The
+	// console_url property is the URL of the web console and kubeconfig is
+	// the path of the admin kubeconfig.
+	InstallComplete Type = "install-complete"
+)
+
+// Event is a single event.
+type Event struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Type      Type              `json:"type"`
+	Data      map[string]string `json:"data,omitempty"`
+}
+
+var (
+	mu     sync.Mutex
+	output io.Writer
+	now    = time.Now
+)
+
+// SetOutput sets the writer the events are written to.  Events are
+// discarded while the output is nil, which is the default.
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	output = w
+}
+
+// Emit writes an event of the given type with the given properties.
+// Failing to write the event is logged, but is not fatal.
+func Emit(t Type, data map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if output == nil {
+		return
+	}
+
+	line, err := json.Marshal(&Event{
+		Timestamp: now().UTC(),
+		Type:      t,
+		Data:      data,
+	})
+	if err != nil {
+		logrus.Warnf("Failed to marshal the %s event: %v", t, err)
+		return
+	}
+	if _, err := output.Write(append(line, '\n')); err != nil {
+		logrus.Warnf("Failed to write the %s event: %v", t, err)
+	}
+}
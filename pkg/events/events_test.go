@@ -0,0 +1,46 @@
+package events
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmit(t *testing.T) {
+	cases := []struct {
+		name     string
+		typ      Type
+		data     map[string]string
+		expected string
+	}{
+		{
+			name:     "without data",
+			typ:      BootstrapComplete,
+			expected: `{"timestamp":"2019-02-01T12:00:00Z","type":"bootstrap-complete"}` + "\n",
+		},
+		{
+			name:     "with data",
+			typ:      AssetGenerated,
+			data:     map[string]string{"asset": "Install Config"},
+			expected: `{"timestamp":"2019-02-01T12:00:00Z","type":"asset-generated","data":{"asset":"Install Config"}}` + "\n",
+		},
+	}
+
+	defer func() { now = time.Now }()
+	now = func() time.Time {
+		return time.Date(2019, 2, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			SetOutput(buf)
+			defer SetOutput(nil)
+
+			Emit(tc.typ, tc.data)
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// +build ignore
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// sources are the packages whose doc comments are collected, keyed by the
+// directory relative to this one.  If types is non-empty, only those types
+// are collected.
+var sources = []struct {
+	dir        string
+	importPath string
+	types      []string
+}{
+	{dir: "../types", importPath: "github.com/openshift/installer/pkg/types"},
+	{dir: "../types/aws", importPath: "github.com/openshift/installer/pkg/types/aws"},
+	{dir: "../types/libvirt", importPath: "github.com/openshift/installer/pkg/types/libvirt"},
+	{dir: "../types/none", importPath: "github.com/openshift/installer/pkg/types/none"},
+	{dir: "../types/openstack", importPath: "github.com/openshift/installer/pkg/types/openstack"},
+	{
+		dir:        "../../vendor/k8s.io/apimachinery/pkg/apis/meta/v1",
+		importPath: "k8s.io/apimachinery/pkg/apis/meta/v1",
+		types:      []string{"ObjectMeta", "TypeMeta"},
+	},
+}
+
+func main() {
+	docs := map[string]string{}
+	for _, source := range sources {
+		fset := token.NewFileSet()
+		pkgs, err := parser.ParseDir(fset, source.dir, func(info os.FileInfo) bool {
+			return !strings.HasSuffix(info.Name(), "_test.go")
+		}, parser.ParseComments)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for _, pkg := range pkgs {
+			for _, file := range pkg.Files {
+				collect(docs, source.importPath, source.types, file)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(docs))
+	for k := range docs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "// Code generated by docs_generate.go; DO NOT EDIT.")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "package explain")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "// docs are the doc comments of the install-config types and their fields,")
+	fmt.Fprintln(buf, "// keyed by \"<import path>.<type>\" and \"<import path>.<type>.<field>\".")
+	fmt.Fprintln(buf, "var docs = map[string]string{")
+	for _, k := range keys {
+		fmt.Fprintf(buf, "\t%q: %q,\n", k, docs[k])
+	}
+	fmt.Fprintln(buf, "}")
+
+	data, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err := ioutil.WriteFile("docs_generated.go", data, 0644); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func collect(docs map[string]string, importPath string, types []string, file *ast.File) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if !typeSpec.Name.IsExported() || !wanted(types, typeSpec.Name.Name) {
+				continue
+			}
+			key := importPath + "." + typeSpec.Name.Name
+			doc := typeSpec.Doc
+			if doc == nil {
+				doc = gen.Doc
+			}
+			if text := doc.Text(); text != "" {
+				docs[key] = strings.TrimSpace(text)
+			}
+
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range structType.Fields.List {
+				text := strings.TrimSpace(field.Doc.Text())
+				if text == "" {
+					continue
+				}
+				for _, name := range fieldNames(field) {
+					docs[key+"."+name] = text
+				}
+			}
+		}
+	}
+}
+
+func wanted(types []string, name string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldNames returns the names of the field, which for embedded fields is
+// the name of the embedded type.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) > 0 {
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		return names
+	}
+
+	expr := field.Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return []string{t.Name}
+	case *ast.SelectorExpr:
+		return []string{t.Sel.Name}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Code generated by docs_generate.go; DO NOT EDIT.
+
+package explain
+
+// docs are the doc comments of the install-config types and their fields,
+// keyed by "<import path>.<type>" and "<import path>.<type>.<field>".
+var docs = map[string]string{
+	"github.com/openshift/installer/pkg/types.ClusterMetadata":                                "ClusterMetadata contains information\nregarding the cluster that was created by installer.",
+	"github.com/openshift/installer/pkg/types.ClusterMetadata.ClusterID":                      "clusterID is a globally unique ID that is used to identify an Openshift cluster.",
+	"github.com/openshift/installer/pkg/types.ClusterMetadata.ClusterName":                    "clusterName is the name for the cluster.",
+	"github.com/openshift/installer/pkg/types.ClusterMetadata.InfraID":                        "infraID is an ID that is used to identify cloud resources created by the installer.",
+	"github.com/openshift/installer/pkg/types.ClusterNetworkEntry":                            "ClusterNetworkEntry is a single IP address block for pod IP blocks. IP blocks\nare allocated with size 2^HostSubnetLength.",
+	"github.com/openshift/installer/pkg/types.ClusterNetworkEntry.CIDR":                       "The IP block address pool\n+required",
+	"github.com/openshift/installer/pkg/types.ClusterNetworkEntry.DeprecatedHostSubnetLength": "The size of blocks to allocate from the larger pool.\nThis is the length in bits - so a 9 here will allocate a /23.",
+	"github.com/openshift/installer/pkg/types.ClusterNetworkEntry.HostPrefix":                 "HostPrefix is the prefix size to allocate to each node from the CIDR.\nFor example, 24 would allocate 2^8=256 adresses to each node.  It\nmay only be omitted when the deprecated HostSubnetLength is set.\n+optional",
+	"github.com/openshift/installer/pkg/types.ClusterPlatformMetadata":                        "ClusterPlatformMetadata contains metadata for platfrom.",
+	"github.com/openshift/installer/pkg/types.ImageContentSource":                             "ImageContentSource defines a list of sources/repositories that can be used\nto pull content.  Images pulled by digest from the source are pulled from\nthe mirrors instead, in order, falling back to the source.",
+	"github.com/openshift/installer/pkg/types.ImageContentSource.Mirrors":                     "Mirrors is one or more repositories that may also contain the same\nimages.\n+required",
+	"github.com/openshift/installer/pkg/types.ImageContentSource.Source":                      "Source is the repository that users refer to, e.g. in image pull\nspecifications.\n+required",
+	"github.com/openshift/installer/pkg/types.InstallConfig":                                  "InstallConfig is the configuration for an OpenShift install.",
+	"github.com/openshift/installer/pkg/types.InstallConfig.AdditionalTrustBundle":            "AdditionalTrustBundle is a PEM-encoded X.509 certificate bundle\nthat will be added to the nodes' trusted certificate store, e.g. to\ntrust the certificates of a proxy or registry signed by a private CA.\n+optional",
+	"github.com/openshift/installer/pkg/types.InstallConfig.BaseDomain":                       "BaseDomain is the base domain to which the cluster should belong.\n+required",
+	"github.com/openshift/installer/pkg/types.InstallConfig.Compute":                          "Compute is the list of compute MachinePools that need to be installed.\n+optional",
+	"github.com/openshift/installer/pkg/types.InstallConfig.ControlPlane":                     "ControlPlane is the configuration for the machines that comprise the\ncontrol plane.\n+optional",
+	"github.com/openshift/installer/pkg/types.InstallConfig.ImageContentSources":              "ImageContentSources lists sources/repositories for the release-image content.\n+optional",
+	"github.com/openshift/installer/pkg/types.InstallConfig.Networking":                       "Networking defines the pod network provider in the cluster.",
+	"github.com/openshift/installer/pkg/types.InstallConfig.Platform":                         "Platform is the configuration for the specific platform upon which to\nperform the installation.",
+	"github.com/openshift/installer/pkg/types.InstallConfig.Proxy":                            "Proxy defines the proxy settings for the cluster.\nIf unset, the cluster will not be configured to use a proxy.\n+optional",
+	"github.com/openshift/installer/pkg/types.InstallConfig.PullSecret":                       "PullSecret is the secret to use when pulling images.\n+required",
+	"github.com/openshift/installer/pkg/types.InstallConfig.SSHKey":                           "SSHKey is the public ssh key to provide access to instances.\n+optional",
+	"github.com/openshift/installer/pkg/types.InstallConfig.TypeMeta":                         "+optional",
+	"github.com/openshift/installer/pkg/types.MachinePool":                                    "MachinePool is a pool of machines to be installed.",
+	"github.com/openshift/installer/pkg/types.MachinePool.Autoscaling":                        "Autoscaling lets the cluster autoscaler scale the machine pool\nbetween the given bounds, starting from Replicas.  It is only\nsupported for compute machine pools.\n+optional",
+	"github.com/openshift/installer/pkg/types.MachinePool.Labels":                             "Labels are added to the nodes of the machine pool.  They are only\nsupported for compute machine pools.\n+optional",
+	"github.com/openshift/installer/pkg/types.MachinePool.Name":                               "Name is the name of the machine pool.\nFor the control plane machine pool, the name will always be \"master\".\nFor the compute machine pools, the name must be unique and a valid\nDNS label other than \"master\", e.g. \"worker\" or \"infra\".",
+	"github.com/openshift/installer/pkg/types.MachinePool.Platform":                           "Platform is configuration for machine pool specific to the platfrom.",
+	"github.com/openshift/installer/pkg/types.MachinePool.Replicas":                           "Replicas is the count of machines for this machine pool.",
+	"github.com/openshift/installer/pkg/types.MachinePool.Taints":                             "Taints are added to the nodes of the machine pool.  They are only\nsupported for compute machine pools.\n+optional",
+	"github.com/openshift/installer/pkg/types.MachinePoolAutoscaling":                         "MachinePoolAutoscaling is the range the cluster autoscaler may scale a\nmachine pool within.  The bounds are split across the machine sets of the\npool in the same way as the replicas, e.g. across availability zones.",
+	"github.com/openshift/installer/pkg/types.MachinePoolAutoscaling.MaxReplicas":             "MaxReplicas is the largest number of machines in the pool.",
+	"github.com/openshift/installer/pkg/types.MachinePoolAutoscaling.MinReplicas":             "MinReplicas is the smallest number of machines in the pool.",
+	"github.com/openshift/installer/pkg/types.MachinePoolPlatform":                            "MachinePoolPlatform is the platform-specific configuration for a machine\npool. Only one of the platforms should be set.",
+	"github.com/openshift/installer/pkg/types.MachinePoolPlatform.AWS":                        "AWS is the configuration used when installing on AWS.",
+	"github.com/openshift/installer/pkg/types.MachinePoolPlatform.Libvirt":                    "Libvirt is the configuration used when installing on libvirt.",
+	"github.com/openshift/installer/pkg/types.MachinePoolPlatform.OpenStack":                  "OpenStack is the configuration used when installing on OpenStack.",
+	"github.com/openshift/installer/pkg/types.Networking":                                     "Networking defines the pod network provider in the cluster.",
+	"github.com/openshift/installer/pkg/types.Networking.ClusterNetwork":                      "ClusterNetwork is the IP address pool to use for pod IPs.  There\nmust be a cluster network for each IP family of the service networks,\nand the first one must have the same family as the first service\nnetwork.\n+optional\nDefault is 10.128.0.0/14 and a host prefix of /23, or fd01::/48 and\na host prefix of /64 for an IPv6 machine CIDR.",
+	"github.com/openshift/installer/pkg/types.Networking.DeprecatedClusterNetworks":           "Deprecated name for ClusterNetwork\n+optional",
+	"github.com/openshift/installer/pkg/types.Networking.DeprecatedServiceCIDR":               "Depcreated name for ServiceNetwork\n+optional",
+	"github.com/openshift/installer/pkg/types.Networking.DeprecatedType":                      "Deprecated name for NetworkType\n+optional",
+	"github.com/openshift/installer/pkg/types.Networking.MachineCIDR":                         "MachineCIDR is the IPv4 or IPv6 address space from which to assign\nmachine IPs.  AWS requires an IPv4 machine CIDR.\n+optional\nDefault is 10.0.0.0/16 for all platforms other than Libvirt.\nFor Libvirt, the default is 192.168.126.0/24.",
+	"github.com/openshift/installer/pkg/types.Networking.NetworkType":                         "NetworkType is the type of network to install.\n+optional\nDefault is OpenShiftSDN.",
+	"github.com/openshift/installer/pkg/types.Networking.ServiceNetwork":                      "ServiceNetwork is the IP address pools to use for service IPs.  The\nfamily of the first one is the primary IP family of the cluster, and\na dual-stack cluster has both IPv4 and IPv6 networks.  The machine,\nservice and cluster networks must not overlap.\n+optional\nDefault is 172.30.0.0/16, or fd02::/112 for an IPv6 machine CIDR.",
+	"github.com/openshift/installer/pkg/types.Platform":                                       "Platform is the configuration for the specific platform upon which to perform\nthe installation. Only one of the platform configuration should be set.",
+	"github.com/openshift/installer/pkg/types.Platform.AWS":                                   "AWS is the configuration used when installing on AWS.\n+optional",
+	"github.com/openshift/installer/pkg/types.Platform.Libvirt":                               "Libvirt is the configuration used when installing on libvirt.\n+optional",
+	"github.com/openshift/installer/pkg/types.Platform.None":                                  "None is the empty configuration used when installing on an unsupported\nplatform.",
+	"github.com/openshift/installer/pkg/types.Platform.OpenStack":                             "OpenStack is the configuration used when installing on OpenStack.\n+optional",
+	"github.com/openshift/installer/pkg/types.Proxy":                                          "Proxy defines the proxy settings for the cluster.\nAt least one of HTTPProxy or HTTPSProxy is required.",
+	"github.com/openshift/installer/pkg/types.Proxy.HTTPProxy":                                "HTTPProxy is the URL of the proxy for HTTP requests.\n+optional",
+	"github.com/openshift/installer/pkg/types.Proxy.HTTPSProxy":                               "HTTPSProxy is the URL of the proxy for HTTPS requests.\n+optional",
+	"github.com/openshift/installer/pkg/types.Proxy.NoProxy":                                  "NoProxy is a comma-separated list of domains and CIDRs for which the\nproxy should not be used.  It should include the machine, service and\ncluster networks.\n+optional",
+	"github.com/openshift/installer/pkg/types/aws.EC2RootVolume":                              "EC2RootVolume defines the storage for an ec2 instance.",
+	"github.com/openshift/installer/pkg/types/aws.EC2RootVolume.IOPS":                         "IOPS defines the iops for the storage.",
+	"github.com/openshift/installer/pkg/types/aws.EC2RootVolume.Size":                         "Size defines the size of the storage.",
+	"github.com/openshift/installer/pkg/types/aws.EC2RootVolume.Type":                         "Type defines the type of the storage.",
+	"github.com/openshift/installer/pkg/types/aws.MachinePool":                                "MachinePool stores the configuration for a machine pool installed\non AWS.",
+	"github.com/openshift/installer/pkg/types/aws.MachinePool.EC2RootVolume":                  "EC2RootVolume defines the storage for ec2 instance.",
+	"github.com/openshift/installer/pkg/types/aws.MachinePool.InstanceType":                   "InstanceType defines the ec2 instance type.\neg. m4-large",
+	"github.com/openshift/installer/pkg/types/aws.MachinePool.Zones":                          "Zones is list of availability zones that can be used.",
+	"github.com/openshift/installer/pkg/types/aws.Metadata":                                   "Metadata contains AWS metadata (e.g. for uninstalling the cluster).",
+	"github.com/openshift/installer/pkg/types/aws.Metadata.Identifier":                        "Identifier holds a slice of filter maps.  The maps hold the\nkey/value pairs for the tags we will be matching against.  A\nresource matches the map if all of the key/value pairs are in its\ntags.  A resource matches Identifier if it matches any of the maps.",
+	"github.com/openshift/installer/pkg/types/aws.Platform":                                   "Platform stores all the global configuration that all machinesets\nuse.",
+	"github.com/openshift/installer/pkg/types/aws.Platform.DefaultMachinePlatform":            "DefaultMachinePlatform is the default configuration used when\ninstalling on AWS for machine pools which do not define their own\nplatform configuration.\n+optional",
+	"github.com/openshift/installer/pkg/types/aws.Platform.Region":                            "Region specifies the AWS region where the cluster will be created.\n+required",
+	"github.com/openshift/installer/pkg/types/aws.Platform.Subnets":                           "Subnets specifies the IDs of existing subnets of a VPC to install\nthe cluster into, instead of creating a new VPC.  The machines are\ncreated in the private subnets, which route to a NAT, and there must\nbe a public subnet, which routes to an internet gateway, in each of\ntheir availability zones.  The subnets must all be in the same VPC,\nand within the machine CIDR.\n+optional",
+	"github.com/openshift/installer/pkg/types/aws.Platform.UserTags":                          "UserTags specifies additional tags for AWS resources created for the cluster.\n+optional",
+	"github.com/openshift/installer/pkg/types/libvirt.MachinePool":                            "MachinePool stores the configuration for a machine pool installed\non libvirt.",
+	"github.com/openshift/installer/pkg/types/libvirt.Metadata":                               "Metadata contains libvirt metadata (e.g. for uninstalling the cluster).",
+	"github.com/openshift/installer/pkg/types/libvirt.Network":                                "Network is the configuration of the libvirt network.",
+	"github.com/openshift/installer/pkg/types/libvirt.Network.IfName":                         "+optional\nDefault is tt0.",
+	"github.com/openshift/installer/pkg/types/libvirt.Platform":                               "Platform stores all the global configuration that all\nmachinesets use.",
+	"github.com/openshift/installer/pkg/types/libvirt.Platform.DefaultMachinePlatform":        "DefaultMachinePlatform is the default configuration used when\ninstalling on libvirt for machine pools which do not define their\nown platform configuration.\n+optional\nDefault will set the image field to the latest RHCOS image.",
+	"github.com/openshift/installer/pkg/types/libvirt.Platform.Network":                       "Network\n+optional",
+	"github.com/openshift/installer/pkg/types/libvirt.Platform.URI":                           "URI is the identifier for the libvirtd connection.  It must be\nreachable from both the host (where the installer is run) and the\ncluster (where the cluster-API controller pod will be running).\n+optional\nDefault is qemu+tcp://192.168.122.1/system",
+	"github.com/openshift/installer/pkg/types/none.Platform":                                  "Platform stores any global configuration used for generic\nplatforms.",
+	"github.com/openshift/installer/pkg/types/openstack.MachinePool":                          "MachinePool stores the configuration for a machine pool installed\non OpenStack.",
+	"github.com/openshift/installer/pkg/types/openstack.MachinePool.FlavorName":               "FlavorName defines the OpenStack Nova flavor.\neg. m1.large",
+	"github.com/openshift/installer/pkg/types/openstack.Metadata":                             "Metadata contains OpenStack metadata (e.g. for uninstalling the cluster).",
+	"github.com/openshift/installer/pkg/types/openstack.Metadata.Identifier":                  "Most OpenStack resources are tagged with these tags as identifier.",
+	"github.com/openshift/installer/pkg/types/openstack.Platform":                             "Platform stores all the global configuration that all\nmachinesets use.",
+	"github.com/openshift/installer/pkg/types/openstack.Platform.Cloud":                       "Cloud\nName of OpenStack cloud to use from clouds.yaml\n+required",
+	"github.com/openshift/installer/pkg/types/openstack.Platform.DefaultMachinePlatform":      "DefaultMachinePlatform is the default configuration used when\ninstalling on OpenStack for machine pools which do not define their own\nplatform configuration.\n+optional",
+	"github.com/openshift/installer/pkg/types/openstack.Platform.ExternalNetwork":             "ExternalNetwork\nThe OpenStack external network name to be used for installation.\n+required",
+	"github.com/openshift/installer/pkg/types/openstack.Platform.FlavorName":                  "FlavorName\nThe OpenStack compute flavor to use for servers.\n+required",
+	"github.com/openshift/installer/pkg/types/openstack.Platform.LbFloatingIP":                "LbFloatingIP\nExisting Floating IP to associate with the OpenStack load balancer.",
+	"github.com/openshift/installer/pkg/types/openstack.Platform.Region":                      "Region specifies the OpenStack region where the cluster will be created.\n+required",
+	"github.com/openshift/installer/pkg/types/openstack.Platform.TrunkSupport":                "TrunkSupport\nWhether OpenStack ports can be trunked",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                                         "ObjectMeta is metadata that all persisted resources must have, which includes all objects\nusers must create.",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.Annotations":                             "Annotations is an unstructured key value map stored with a resource that may be\nset by external tools to store and retrieve arbitrary metadata. They are not\nqueryable and should be preserved when modifying objects.\nMore info: http://kubernetes.io/docs/user-guide/annotations\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.ClusterName":                             "The name of the cluster which the object belongs to.\nThis is used to distinguish resources with same name and namespace in different clusters.\nThis field is not set anywhere right now and apiserver is going to ignore it if set in create or update request.\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.CreationTimestamp":                       "CreationTimestamp is a timestamp representing the server time when this object was\ncreated. It is not guaranteed to be set in happens-before order across separate operations.\nClients may not set this value. It is represented in RFC3339 form and is in UTC.\n\nPopulated by the system.\nRead-only.\nNull for lists.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.DeletionGracePeriodSeconds":              "Number of seconds allowed for this object to gracefully terminate before\nit will be removed from the system. Only set when deletionTimestamp is also set.\nMay only be shortened.\nRead-only.\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.DeletionTimestamp":                       "DeletionTimestamp is RFC 3339 date and time at which this resource will be deleted. This\nfield is set by the server when a graceful deletion is requested by the user, and is not\ndirectly settable by a client. The resource is expected to be deleted (no longer visible\nfrom resource lists, and not reachable by name) after the time in this field, once the\nfinalizers list is empty. As long as the finalizers list contains items, deletion is blocked.\nOnce the deletionTimestamp is set, this value may not be unset or be set further into the\nfuture, although it may be shortened or the resource may be deleted prior to this time.\nFor example, a user may request that a pod is deleted in 30 seconds. The Kubelet will react\nby sending a graceful termination signal to the containers in the pod. After that 30 seconds,\nthe Kubelet will send a hard termination signal (SIGKILL) to the container and after cleanup,\nremove the pod from the API. In the presence of network partitions, this object may still\nexist after this timestamp, until an administrator or automated process can determine the\nresource is fully terminated.\nIf not set, graceful deletion of the object has not been requested.\n\nPopulated by the system when a graceful deletion is requested.\nRead-only.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.Finalizers":                              "Must be empty before the object is deleted from the registry. Each entry\nis an identifier for the responsible component that will remove the entry\nfrom the list. If the deletionTimestamp of the object is non-nil, entries\nin this list can only be removed.\n+optional\n+patchStrategy=merge",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.GenerateName":                            "GenerateName is an optional prefix, used by the server, to generate a unique\nname ONLY IF the Name field has not been provided.\nIf this field is used, the name returned to the client will be different\nthan the name passed. This value will also be combined with a unique suffix.\nThe provided value has the same validation rules as the Name field,\nand may be truncated by the length of the suffix required to make the value\nunique on the server.\n\nIf this field is specified and the generated name exists, the server will\nNOT return a 409 - instead, it will either return 201 Created or 500 with Reason\nServerTimeout indicating a unique name could not be found in the time allotted, and the client\nshould retry (optionally after the time indicated in the Retry-After header).\n\nApplied only if Name is not specified.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#idempotency\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.Generation":                              "A sequence number representing a specific generation of the desired state.\nPopulated by the system. Read-only.\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.Initializers":                            "An initializer is a controller which enforces some system invariant at object creation time.\nThis field is a list of initializers that have not yet acted on this object. If nil or empty,\nthis object has been completely initialized. Otherwise, the object is considered uninitialized\nand is hidden (in list/watch and get calls) from clients that haven't explicitly asked to\nobserve uninitialized objects.\n\nWhen an object is created, the system will populate this list with the current set of initializers.\nOnly privileged users may set or modify this list. Once it is empty, it may not be modified further\nby any user.",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.Labels":                                  "Map of string keys and values that can be used to organize and categorize\n(scope and select) objects. May match selectors of replication controllers\nand services.\nMore info: http://kubernetes.io/docs/user-guide/labels\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.Name":                                    "Name must be unique within a namespace. Is required when creating resources, although\nsome resources may allow a client to request the generation of an appropriate name\nautomatically. Name is primarily intended for creation idempotence and configuration\ndefinition.\nCannot be updated.\nMore info: http://kubernetes.io/docs/user-guide/identifiers#names\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.Namespace":                               "Namespace defines the space within each name must be unique. An empty namespace is\nequivalent to the \"default\" namespace, but \"default\" is the canonical representation.\nNot all objects are required to be scoped to a namespace - the value of this field for\nthose objects will be empty.\n\nMust be a DNS_LABEL.\nCannot be updated.\nMore info: http://kubernetes.io/docs/user-guide/namespaces\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.OwnerReferences":                         "List of objects depended by this object. If ALL objects in the list have\nbeen deleted, this object will be garbage collected. If this object is managed by a controller,\nthen an entry in this list will point to this controller, with the controller field set to true.\nThere cannot be more than one managing controller.\n+optional\n+patchMergeKey=uid\n+patchStrategy=merge",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.ResourceVersion":                         "An opaque value that represents the internal version of this object that can\nbe used by clients to determine when objects have changed. May be used for optimistic\nconcurrency, change detection, and the watch operation on a resource or set of resources.\nClients must treat these values as opaque and passed unmodified back to the server.\nThey may only be valid for a particular resource or set of resources.\n\nPopulated by the system.\nRead-only.\nValue must be treated as opaque by clients and .\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#concurrency-control-and-consistency\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.SelfLink":                                "SelfLink is a URL representing this object.\nPopulated by the system.\nRead-only.\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.UID":                                     "UID is the unique in time and space value for this object. It is typically generated by\nthe server on successful creation of a resource and is not allowed to change on PUT\noperations.\n\nPopulated by the system.\nRead-only.\nMore info: http://kubernetes.io/docs/user-guide/identifiers#uids\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                                           "TypeMeta describes an individual object in an API response or request\nwith strings representing the type of the object and its API schema version.\nStructures that are versioned or persisted should inline TypeMeta.\n\n+k8s:deepcopy-gen=false",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta.APIVersion":                                "APIVersion defines the versioned schema of this representation of an object.\nServers should convert recognized schemas to the latest internal value, and\nmay reject unrecognized values.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources\n+optional",
+	"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta.Kind":                                      "Kind is a string value representing the REST resource this object represents.\nServers may infer this from the endpoint the client submits requests to.\nCannot be updated.\nIn CamelCase.\nMore info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds\n+optional",
+}
@@ -0,0 +1,374 @@
+//go:generate go run docs_generate.go
+
+// Package explain describes the install-config fields from the Go types, in
+// the spirit of 'kubectl explain', and as a JSON Schema.
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/defaults"
+)
+
+const (
+	// RootName is the first element of every path that can be explained.
+	RootName = "installconfig"
+
+	optionalMarker = "+optional"
+	requiredMarker = "+required"
+
+	// deprecatedPrefix starts the Go names of the deprecated fields.
+	deprecatedPrefix = "Deprecated"
+)
+
+var (
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Field describes a single field of an install-config type.
+type Field struct {
+	// Name is the name of the field in the install-config.
+	Name string
+
+	// Type is a human-friendly description of the field's type.
+	Type string
+
+	// Description is the doc comment of the field.
+	Description string
+
+	// Required is true if the field may not be omitted.  Only fields whose
+	// doc comments are marked with "+required" are, because the installer
+	// fills in most of the others, some of them only after validation.
+	Required bool
+
+	// Deprecated is true if the field is only accepted for older
+	// install-configs and has been replaced by another field.
+	Deprecated bool
+
+	// Default is the JSON value the field is set to when omitted, or empty
+	// if it has no default.
+	Default string
+
+	goType  reflect.Type
+	goField reflect.StructField
+}
+
+// Explain writes the description of the install-config field at the given
+// dot-separated path (e.g. "installconfig.platform.aws") to w.
+func Explain(w io.Writer, path string) error {
+	field, children, err := Lookup(path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "KIND:     InstallConfig\n")
+	fmt.Fprintf(w, "VERSION:  %s\n\n", types.InstallConfigVersion)
+	resource := fmt.Sprintf("RESOURCE: %s <%s>", field.Name, field.Type)
+	if field.Deprecated {
+		resource += " -deprecated-"
+	}
+	fmt.Fprintln(w, resource)
+	if field.Default != "" {
+		fmt.Fprintf(w, "DEFAULT:  %s\n", field.Default)
+	}
+	fmt.Fprintf(w, "\nDESCRIPTION:\n")
+	writeIndented(w, "    ", field.Description)
+
+	if len(children) > 0 {
+		fmt.Fprintf(w, "\nFIELDS:\n")
+		for i, child := range children {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			line := fmt.Sprintf("    %s <%s>", child.Name, child.Type)
+			if child.Required {
+				line += " -required-"
+			}
+			if child.Deprecated {
+				line += " -deprecated-"
+			}
+			fmt.Fprintln(w, line)
+			writeIndented(w, "      ", child.Description)
+			if child.Default != "" {
+				fmt.Fprintf(w, "      Default: %s\n", child.Default)
+			}
+		}
+	}
+	return nil
+}
+
+// Lookup returns the install-config field at the given dot-separated path
+// and the fields it contains, sorted by name.
+func Lookup(path string) (*Field, []Field, error) {
+	elements := strings.Split(path, ".")
+	if !strings.EqualFold(elements[0], RootName) {
+		return nil, nil, errors.Errorf("%q does not start with %q", path, RootName)
+	}
+
+	// Walk the defaulted install-config along with its type, so that the
+	// defaults of the fields on the path can be reported.  Nil pointers on
+	// the path are allocated and the defaults re-applied, so that e.g. the
+	// defaults for a platform are set once that platform is selected.
+	config := &types.InstallConfig{}
+	defaults.SetInstallConfigDefaults(config)
+
+	rootType := reflect.TypeOf(config).Elem()
+	field := &Field{
+		Name:        RootName,
+		Type:        typeName(rootType),
+		Description: typeDescription(rootType),
+		goType:      rootType,
+	}
+	value := reflect.ValueOf(config).Elem()
+	for i, name := range elements[1:] {
+		children := fields(field.goType, value)
+		var next *Field
+		for j := range children {
+			if children[j].Name == name {
+				next = &children[j]
+				break
+			}
+		}
+		if next == nil {
+			return nil, nil, errors.Errorf("field %q does not exist in %q", name, strings.Join(elements[:i+1], "."))
+		}
+
+		fieldValue := fieldByIndex(value, next.goField.Index)
+		if fieldValue.IsValid() && fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil() {
+			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+			defaults.SetInstallConfigDefaults(config)
+		}
+		value = elem(fieldValue)
+
+		field = next
+		if field.Description == "" {
+			field.Description = typeDescription(field.goType)
+		}
+	}
+
+	return field, fields(field.goType, value), nil
+}
+
+// fields returns the fields of the struct type t, with their defaults
+// taken from the value v (which may be invalid if there is no value).
+func fields(t reflect.Type, v reflect.Value) []Field {
+	if t.Kind() != reflect.Struct || t.Implements(marshalerType) {
+		return nil
+	}
+
+	var result []Field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if structField.PkgPath != "" {
+			continue
+		}
+
+		name, inline := parseTag(structField)
+		if name == "-" {
+			continue
+		}
+		var fieldValue reflect.Value
+		if v.IsValid() {
+			fieldValue = v.Field(i)
+		}
+		if inline {
+			for _, f := range fields(indirect(structField.Type), elem(fieldValue)) {
+				f.goField.Index = append([]int{i}, f.goField.Index...)
+				result = append(result, f)
+			}
+			continue
+		}
+
+		description := docs[docKey(t)+"."+structField.Name]
+		required := false
+		var lines []string
+		for _, line := range strings.Split(description, "\n") {
+			switch strings.TrimSpace(line) {
+			case optionalMarker:
+				continue
+			case requiredMarker:
+				required = true
+				continue
+			}
+			lines = append(lines, line)
+		}
+
+		result = append(result, Field{
+			Name:        name,
+			Type:        typeName(structField.Type),
+			Description: strings.TrimSpace(strings.Join(lines, "\n")),
+			Required:    required,
+			Deprecated:  strings.HasPrefix(structField.Name, deprecatedPrefix),
+			Default:     defaultValue(fieldValue),
+			goType:      indirectElem(structField.Type),
+			goField:     structField,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// parseTag returns the JSON name of the field and whether its fields are
+// inlined into the parent.
+func parseTag(field reflect.StructField) (name string, inline bool) {
+	tag := strings.Split(field.Tag.Get("json"), ",")
+	name = tag[0]
+	for _, option := range tag[1:] {
+		if option == "inline" {
+			inline = true
+		}
+	}
+	if name == "" {
+		if field.Anonymous {
+			inline = true
+		}
+		name = field.Name
+	}
+	return name, inline
+}
+
+// defaultValue returns the JSON representation of v, or an empty string if
+// v is unset or is a struct with fields of its own.
+func defaultValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return ""
+	}
+	if reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface()) {
+		return ""
+	}
+	if t := indirect(v.Type()); t.Kind() == reflect.Struct && !t.Implements(marshalerType) {
+		return ""
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// typeName returns a human-friendly name for the type.
+func typeName(t reflect.Type) string {
+	t = indirect(t)
+	if t.Implements(marshalerType) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "[]" + typeName(t.Elem())
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", typeName(t.Key()), typeName(t.Elem()))
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return t.Kind().String()
+}
+
+// typeDescription returns the doc comment of a named type.
+func typeDescription(t reflect.Type) string {
+	if t.Name() == "" {
+		return ""
+	}
+	return docs[docKey(t)]
+}
+
+// docKey returns the key of the type in docs.
+func docKey(t reflect.Type) string {
+	pkgPath := t.PkgPath()
+	if i := strings.LastIndex(pkgPath, "/vendor/"); i >= 0 {
+		pkgPath = pkgPath[i+len("/vendor/"):]
+	}
+	return pkgPath + "." + t.Name()
+}
+
+// indirect dereferences pointer types.
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// indirectElem dereferences pointer types and returns the element type of
+// slices and maps, which is the type that the next path element refers to.
+func indirectElem(t reflect.Type) reflect.Type {
+	t = indirect(t)
+	for (t.Kind() == reflect.Slice || t.Kind() == reflect.Map || t.Kind() == reflect.Array) && !t.Implements(marshalerType) {
+		t = indirect(t.Elem())
+	}
+	return t
+}
+
+// elem dereferences pointers and takes the first element of slices, to
+// follow the value along with indirectElem.  It returns an invalid value
+// if there is nothing to follow.
+func elem(v reflect.Value) reflect.Value {
+	for v.IsValid() {
+		switch v.Kind() {
+		case reflect.Ptr:
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		case reflect.Slice, reflect.Array:
+			if v.Type().Implements(marshalerType) {
+				return v
+			}
+			if v.Len() == 0 {
+				return reflect.Value{}
+			}
+			v = v.Index(0)
+		case reflect.Map:
+			return reflect.Value{}
+		default:
+			return v
+		}
+	}
+	return v
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but returns an invalid
+// value instead of panicking on invalid values and nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if !v.IsValid() {
+			return v
+		}
+		if i > 0 {
+			v = elem(v)
+			if !v.IsValid() {
+				return v
+			}
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func writeIndented(w io.Writer, indent string, text string) {
+	if text == "" {
+		fmt.Fprintf(w, "%s<empty>\n", indent)
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(w, "%s%s\n", indent, line)
+	}
+}
@@ -0,0 +1,164 @@
+package explain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		name             string
+		path             string
+		expectedType     string
+		expectedChildren map[string]Field
+		expectedError    string
+	}{
+		{
+			name:         "root",
+			path:         "installconfig",
+			expectedType: "object",
+			expectedChildren: map[string]Field{
+				"apiVersion": {Type: "string"},
+				"baseDomain": {Type: "string", Required: true},
+				"compute":    {Type: "[]object", Default: `[{"name":"worker","replicas":3,"platform":{}}]`},
+				"sshKey":     {Type: "string"},
+			},
+		},
+		{
+			name:         "networking defaults",
+			path:         "installconfig.networking",
+			expectedType: "object",
+			expectedChildren: map[string]Field{
+				"machineCIDR":    {Type: "string", Default: `"10.0.0.0/16"`},
+				"networkType":    {Type: "string", Default: `"OpenShiftSDN"`},
+				"serviceNetwork": {Type: "[]string", Default: `["172.30.0.0/16"]`},
+				"serviceCIDR":    {Type: "string", Deprecated: true},
+				"type":           {Type: "string", Deprecated: true},
+			},
+		},
+		{
+			name:         "platform defaults",
+			path:         "installconfig.platform.libvirt",
+			expectedType: "object",
+			expectedChildren: map[string]Field{
+				"URI": {Type: "string", Default: `"qemu+tcp://192.168.122.1/system"`},
+			},
+		},
+		{
+			name:         "through a slice",
+			path:         "installconfig.compute.platform.aws",
+			expectedType: "object",
+			expectedChildren: map[string]Field{
+				"type":  {Type: "string"},
+				"zones": {Type: "[]string"},
+			},
+		},
+		{
+			name:          "bad root",
+			path:          "machinepool",
+			expectedError: `^"machinepool" does not start with "installconfig"$`,
+		},
+		{
+			name:          "unknown field",
+			path:          "installconfig.platform.azure",
+			expectedError: `^field "azure" does not exist in "installconfig.platform"$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			field, children, err := Lookup(tc.path)
+			if tc.expectedError != "" {
+				assert.Regexp(t, tc.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedType, field.Type)
+			byName := map[string]Field{}
+			for _, child := range children {
+				byName[child.Name] = child
+			}
+			for name, expected := range tc.expectedChildren {
+				child, ok := byName[name]
+				if !assert.True(t, ok, "missing field %q", name) {
+					continue
+				}
+				assert.Equal(t, expected.Type, child.Type, "type of %q", name)
+				assert.Equal(t, expected.Required, child.Required, "required %q", name)
+				assert.Equal(t, expected.Deprecated, child.Deprecated, "deprecated %q", name)
+				assert.Equal(t, expected.Default, child.Default, "default of %q", name)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	cases := []struct {
+		name         string
+		path         string
+		expected     []string
+		expectedOnce []string
+	}{
+		{
+			name: "defaulted field",
+			path: "installconfig.controlPlane.replicas",
+			expected: []string{
+				"RESOURCE: replicas <integer>\nDEFAULT:  3\n",
+			},
+			expectedOnce: []string{
+				"Replicas is the count of machines for this machine pool.",
+			},
+		},
+		{
+			name: "field of a named type",
+			path: "installconfig.networking",
+			expected: []string{
+				"    machineCIDR <string>\n",
+			},
+			expectedOnce: []string{
+				"Networking defines the pod network provider in the cluster.",
+			},
+		},
+		{
+			name: "required and deprecated fields",
+			path: "installconfig.networking.clusterNetwork",
+			expected: []string{
+				"    cidr <string> -required-\n",
+				"    hostPrefix <integer>\n",
+				"    hostSubnetLength <integer> -deprecated-\n",
+			},
+		},
+		{
+			name: "fields defaulted later are not required",
+			path: "installconfig.platform.openstack",
+			expected: []string{
+				"    cloud <string> -required-\n",
+				"    lbFloatingIP <string>\n",
+				"    trunkSupport <string>\n",
+			},
+		},
+		{
+			name: "fields defaulted from the machine pool are not required",
+			path: "installconfig.compute.platform.aws.rootVolume",
+			expected: []string{
+				"    iops <integer>\n",
+				"    type <string>\n",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := Explain(buf, tc.path)
+			assert.NoError(t, err)
+			for _, expected := range tc.expected {
+				assert.Contains(t, buf.String(), expected)
+			}
+			for _, expected := range tc.expectedOnce {
+				assert.Equal(t, 1, strings.Count(buf.String(), expected), "%q in\n%s", expected, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,388 @@
+package explain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/defaults"
+)
+
+const (
+	// SchemaVersion is the JSON Schema draft that the generated schema uses.
+	SchemaVersion = "http://json-schema.org/draft-07/schema#"
+
+	definitionsPrefix = "#/definitions/"
+)
+
+// Schema is a JSON Schema document, or a schema within one.  Only the
+// keywords needed to describe the install-config types are supported.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+
+	// Default is the JSON value the field is set to when omitted.
+	Default json.RawMessage `json:"default,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+
+	// AdditionalProperties is either false, for structs, or the schema of
+	// the values, for maps.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+
+	Items *Schema `json:"items,omitempty"`
+
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// InstallConfigSchema generates the JSON Schema of the install-config from
+// the Go types.  Every struct type becomes a definition, with the doc
+// comments of its fields as descriptions and their defaults as defaults.
+// Fields are required if their doc comments are marked with "+required".  A
+// type used in several places, like MachinePool for both the control plane
+// and the compute pools, only has the defaults that every place agrees on.
+func InstallConfigSchema() (*Schema, error) {
+	g := &generator{
+		definitions: map[string]*Schema{},
+		names:       map[string]reflect.Type{},
+		visiting:    map[reflect.Type]bool{},
+	}
+	rootType := reflect.TypeOf(types.InstallConfig{})
+	root, err := g.schema(nil, rootType)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{
+		Schema:      SchemaVersion,
+		Ref:         root.Ref,
+		Title:       "InstallConfig " + types.InstallConfigVersion,
+		Description: typeDescription(rootType),
+		Definitions: g.definitions,
+	}, nil
+}
+
+type generator struct {
+	definitions map[string]*Schema
+	names       map[string]reflect.Type
+	visiting    map[reflect.Type]bool
+}
+
+// schema returns the schema for the type t of the field reached through the
+// struct fields with the given indexes, adding the definitions of struct
+// types as they are reached.
+func (g *generator) schema(steps [][]int, t reflect.Type) (*Schema, error) {
+	t = indirect(t)
+	if t.Implements(marshalerType) {
+		return &Schema{Type: "string"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		name, err := g.definitionName(t)
+		if err != nil {
+			return nil, err
+		}
+		ref := &Schema{Ref: definitionsPrefix + name}
+		if g.visiting[t] {
+			return ref, nil
+		}
+		g.visiting[t] = true
+		defer delete(g.visiting, t)
+
+		definition, err := g.object(steps, t)
+		if err != nil {
+			return nil, err
+		}
+		if existing, ok := g.definitions[name]; ok {
+			merge(existing, definition)
+		} else {
+			g.definitions[name] = definition
+		}
+		return ref, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.schema(steps, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, errors.Errorf("map keys of type %s are not supported", t.Key())
+		}
+		values, err := g.schema(steps, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	}
+	switch name := typeName(t); name {
+	case "boolean", "integer", "number":
+		return &Schema{Type: name}, nil
+	}
+	return nil, errors.Errorf("type %s is not supported", t)
+}
+
+// object returns the definition of the struct type t, which is the type of
+// the field reached through the given steps.
+func (g *generator) object(steps [][]int, t reflect.Type) (*Schema, error) {
+	definition := &Schema{
+		Type:                 "object",
+		Description:          typeDescription(t),
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+	for _, child := range fields(t, defaultedValue(steps)) {
+		property, err := g.schema(append(steps[:len(steps):len(steps)], child.goField.Index), child.goField.Type)
+		if err != nil {
+			return nil, err
+		}
+		property.Description = child.Description
+		if child.Default != "" {
+			property.Default = json.RawMessage(child.Default)
+		}
+		definition.Properties[child.Name] = property
+		if child.Required {
+			definition.Required = append(definition.Required, child.Name)
+		}
+	}
+	return definition, nil
+}
+
+// defaultedValue returns the value of the field reached through the given
+// steps in a defaulted install-config, or an invalid value if there is none.
+// Like Lookup, nil pointers on the way are allocated and the defaults
+// re-applied.  Slices are replaced by a single empty element, so that the
+// defaults found are those of an element the user provides rather than
+// those of a defaulted slice.
+func defaultedValue(steps [][]int) reflect.Value {
+	config := &types.InstallConfig{}
+	defaults.SetInstallConfigDefaults(config)
+
+	v := reflect.ValueOf(config).Elem()
+	for _, index := range steps {
+		v = fieldByIndex(v, index)
+	follow:
+		for v.IsValid() {
+			switch {
+			case v.Type().Implements(marshalerType):
+				break follow
+			case v.Kind() == reflect.Ptr:
+				if v.IsNil() {
+					v.Set(reflect.New(v.Type().Elem()))
+					defaults.SetInstallConfigDefaults(config)
+				}
+				v = v.Elem()
+			case v.Kind() == reflect.Slice:
+				v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+				defaults.SetInstallConfigDefaults(config)
+				v = v.Index(0)
+			case v.Kind() == reflect.Map:
+				return reflect.Value{}
+			default:
+				break follow
+			}
+		}
+	}
+	return v
+}
+
+// merge restricts the defaults of the definition a to those it has in
+// common with b, the definition of the same type in another place.
+func merge(a, b *Schema) {
+	for name, property := range a.Properties {
+		if !bytes.Equal(property.Default, b.Properties[name].Default) {
+			property.Default = nil
+		}
+	}
+}
+
+// definitionName returns the name of the definition of the struct type t,
+// which is the type name qualified by the last element of its package.
+func (g *generator) definitionName(t reflect.Type) (string, error) {
+	if t.Name() == "" {
+		return "", errors.Errorf("anonymous struct %s is not supported", t)
+	}
+	name := path.Base(t.PkgPath()) + "." + t.Name()
+	if other, ok := g.names[name]; ok && other != t {
+		return "", errors.Errorf("types %s and %s have the same definition name %q", docKey(other), docKey(t), name)
+	}
+	g.names[name] = t
+	return name, nil
+}
+
+// ValidateSchema validates a decoded install-config against the schema from
+// InstallConfigSchema.  Like the installer's YAML decoding, null values are
+// treated as omitted and scalars are accepted for strings.
+//
+// Fields that are not in the schema are returned separately as unknown,
+// with the closest field that is suggested, so that the caller can decide
+// whether they are errors.  Field names are case-sensitive in the schema,
+// although the installer's YAML decoding falls back to case-insensitive
+// matches.
+func ValidateSchema(config map[string]interface{}) (allErrs field.ErrorList, unknown field.ErrorList) {
+	schema, err := InstallConfigSchema()
+	if err != nil {
+		return field.ErrorList{field.InternalError(nil, err)}, nil
+	}
+	v := &validator{definitions: schema.Definitions}
+	v.validate(schema, config, nil)
+	return v.errors, v.unknown
+}
+
+type validator struct {
+	definitions map[string]*Schema
+	errors      field.ErrorList
+	unknown     field.ErrorList
+}
+
+func (v *validator) validate(schema *Schema, value interface{}, fldPath *field.Path) {
+	if schema.Ref != "" {
+		definition, ok := v.definitions[strings.TrimPrefix(schema.Ref, definitionsPrefix)]
+		if !ok {
+			v.errors = append(v.errors, field.InternalError(fldPath, errors.Errorf("unknown reference %q", schema.Ref)))
+			return
+		}
+		schema = definition
+	}
+	if value == nil {
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			v.invalidType(schema, value, fldPath)
+			return
+		}
+		for _, name := range schema.Required {
+			if object[name] == nil {
+				v.errors = append(v.errors, field.Required(fldPath.Child(name), ""))
+			}
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, ok := schema.Properties[key]; ok {
+				v.validate(property, object[key], fldPath.Child(key))
+				continue
+			}
+			switch additional := schema.AdditionalProperties.(type) {
+			case *Schema:
+				v.validate(additional, object[key], fldPath.Key(key))
+			case bool:
+				if !additional {
+					detail := "unknown field"
+					if suggestion := closestName(key, schema.Properties); suggestion != "" {
+						detail = fmt.Sprintf("%s, did you mean %q?", detail, suggestion)
+					}
+					v.unknown = append(v.unknown, field.Forbidden(fldPath.Child(key), detail))
+				}
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			v.invalidType(schema, value, fldPath)
+			return
+		}
+		for i, item := range array {
+			v.validate(schema.Items, item, fldPath.Index(i))
+		}
+	case "string":
+		switch value.(type) {
+		case string, float64, bool:
+		default:
+			v.invalidType(schema, value, fldPath)
+		}
+	case "integer":
+		if number, ok := value.(float64); !ok || number != math.Trunc(number) {
+			v.invalidType(schema, value, fldPath)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			v.invalidType(schema, value, fldPath)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.invalidType(schema, value, fldPath)
+		}
+	}
+}
+
+func (v *validator) invalidType(schema *Schema, value interface{}, fldPath *field.Path) {
+	switch value.(type) {
+	case map[string]interface{}:
+		value = "object"
+	case []interface{}:
+		value = "array"
+	}
+	v.errors = append(v.errors, field.Invalid(fldPath, value, fmt.Sprintf("must be of type %s", schema.Type)))
+}
+
+// closestName returns the name of the property that the unknown name is most
+// likely a misspelling of, or an empty string if none is close enough.
+func closestName(name string, properties map[string]*Schema) string {
+	names := make([]string, 0, len(properties))
+	for property := range properties {
+		names = append(names, property)
+	}
+	sort.Strings(names)
+
+	closest, closestDistance := "", 0
+	for _, property := range names {
+		distance := editDistance(strings.ToLower(name), strings.ToLower(property))
+		if closest == "" || distance < closestDistance {
+			closest, closestDistance = property, distance
+		}
+	}
+	if closest == "" || closestDistance > 2 && closestDistance > len(name)/3 {
+		return ""
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
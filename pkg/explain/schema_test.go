@@ -0,0 +1,268 @@
+package explain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallConfigSchema(t *testing.T) {
+	schema, err := InstallConfigSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "#/definitions/types.InstallConfig", schema.Ref)
+
+	root := schema.Definitions["types.InstallConfig"]
+	if !assert.NotNil(t, root) {
+		return
+	}
+	assert.Equal(t, []string{"baseDomain", "pullSecret"}, root.Required)
+	assert.Equal(t, false, root.AdditionalProperties)
+	assert.Equal(t, "#/definitions/types.MachinePool", root.Properties["compute"].Items.Ref)
+	assert.Equal(t, "Compute is the list of compute MachinePools that need to be installed.", root.Properties["compute"].Description)
+
+	pool := schema.Definitions["types.MachinePool"]
+	if !assert.NotNil(t, pool) {
+		return
+	}
+	assert.Empty(t, pool.Required, "the control plane name is defaulted")
+	assert.Empty(t, schema.Definitions["aws.MachinePool"].Required, "the instance type is defaulted")
+	assert.Empty(t, schema.Definitions["aws.EC2RootVolume"].Required, "the root volume is defaulted")
+	assert.Equal(t, []string{"cloud", "computeFlavor", "externalNetwork", "region"}, schema.Definitions["openstack.Platform"].Required)
+	assert.Equal(t, json.RawMessage("3"), pool.Properties["replicas"].Default)
+	assert.Equal(t, &Schema{Type: "string"}, pool.Properties["labels"].AdditionalProperties)
+
+	entry := schema.Definitions["types.ClusterNetworkEntry"]
+	if !assert.NotNil(t, entry) {
+		return
+	}
+	assert.Equal(t, []string{"cidr"}, entry.Required)
+	assert.Nil(t, entry.Properties["cidr"].Default, "the default cluster network is not a default for each entry")
+	assert.Equal(t, "string", entry.Properties["cidr"].Type)
+
+	source := schema.Definitions["types.ImageContentSource"]
+	if !assert.NotNil(t, source) {
+		return
+	}
+	assert.Equal(t, []string{"mirrors", "source"}, source.Required)
+
+	libvirt := schema.Definitions["libvirt.Platform"]
+	if !assert.NotNil(t, libvirt) {
+		return
+	}
+	assert.Equal(t, json.RawMessage(`"qemu+tcp://192.168.122.1/system"`), libvirt.Properties["URI"].Default)
+	assert.Empty(t, libvirt.Required)
+
+	for _, name := range []string{"aws.Platform", "aws.MachinePool", "openstack.Platform", "openstack.MachinePool", "none.Platform"} {
+		assert.Contains(t, schema.Definitions, name)
+	}
+
+	_, err = json.Marshal(schema)
+	assert.NoError(t, err)
+}
+
+func TestValidateSchema(t *testing.T) {
+	cases := []struct {
+		name            string
+		config          string
+		expectedErrors  []string
+		expectedUnknown []string
+	}{
+		{
+			name: "valid",
+			config: `
+apiVersion: v1beta4
+baseDomain: example.com
+metadata:
+  name: test
+  labels:
+    team: installer
+networking:
+  clusterNetwork:
+  - cidr: 10.128.0.0/14
+    hostPrefix: 23
+compute:
+- name: worker
+  replicas: 3
+  platform:
+    aws:
+      type: m4.large
+      zones: [us-east-1a]
+platform:
+  aws:
+    region: us-east-1
+pullSecret: '{"auths":{}}'
+`,
+		},
+		{
+			name: "wrong types",
+			config: `
+baseDomain: example.com
+networking:
+  clusterNetwork:
+  - cidr: 10.128.0.0/14
+    hostPrefix: "23"
+compute: worker
+controlPlane:
+  replicas: 1.5
+pullSecret: '{"auths":{}}'
+`,
+			expectedErrors: []string{
+				`compute: Invalid value: "worker": must be of type array`,
+				`controlPlane.replicas: Invalid value: 1.5: must be of type integer`,
+				`networking.clusterNetwork[0].hostPrefix: Invalid value: "23": must be of type integer`,
+			},
+		},
+		{
+			name: "missing required fields",
+			config: `
+networking:
+  clusterNetwork:
+  - hostPrefix: 23
+platform:
+  aws: {}
+pullSecret:
+`,
+			expectedErrors: []string{
+				`baseDomain: Required value`,
+				`pullSecret: Required value`,
+				`networking.clusterNetwork[0].cidr: Required value`,
+				`platform.aws.region: Required value`,
+			},
+		},
+		{
+			name: "scalars for strings",
+			config: `
+baseDomain: example.com
+platform:
+  openstack:
+    cloud: 1
+    computeFlavor: m1.large
+    externalNetwork: external
+    lbFloatingIP: 10.0.0.1
+    region: region1
+    trunkSupport: true
+pullSecret: '{"auths":{}}'
+`,
+		},
+		{
+			name: "defaulted machine pool instance type",
+			config: `
+baseDomain: example.com
+controlPlane:
+  platform:
+    aws:
+      zones: [us-east-1a]
+platform:
+  aws:
+    region: us-east-1
+pullSecret: '{"auths":{}}'
+`,
+		},
+		{
+			name: "partial root volume",
+			config: `
+baseDomain: example.com
+compute:
+- name: worker
+  platform:
+    aws:
+      rootVolume:
+        size: 200
+platform:
+  aws:
+    region: us-east-1
+pullSecret: '{"auths":{}}'
+`,
+		},
+		{
+			name: "openstack without load balancer floating IP and trunk support",
+			config: `
+baseDomain: example.com
+platform:
+  openstack:
+    cloud: standalone
+    computeFlavor: m1.medium
+    externalNetwork: public
+    region: regionOne
+pullSecret: '{"auths":{}}'
+`,
+		},
+		{
+			name: "unknown fields",
+			config: `
+baseDomain: example.com
+controlplane:
+  replicas: 3
+frobnicate: true
+networking:
+  machineCidr: 10.0.0.0/16
+pullSecret: '{"auths":{}}'
+sshKeys: ssh-rsa AAAA
+`,
+			expectedUnknown: []string{
+				`controlplane: Forbidden: unknown field, did you mean "controlPlane"?`,
+				`frobnicate: Forbidden: unknown field`,
+				`networking.machineCidr: Forbidden: unknown field, did you mean "machineCIDR"?`,
+				`sshKeys: Forbidden: unknown field, did you mean "sshKey"?`,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var config map[string]interface{}
+			if err := yaml.Unmarshal([]byte(tc.config), &config); err != nil {
+				t.Fatal(err)
+			}
+
+			var errs, unknown []string
+			allErrs, allUnknown := ValidateSchema(config)
+			for _, err := range allErrs {
+				errs = append(errs, err.Error())
+			}
+			for _, err := range allUnknown {
+				unknown = append(unknown, err.Error())
+			}
+			assert.Equal(t, tc.expectedErrors, errs)
+			assert.Equal(t, tc.expectedUnknown, unknown)
+		})
+	}
+}
+
+// TestDocsExamples checks that the install-config examples in the user
+// documentation only use fields that are in the schema, so that they are
+// not rejected without --lenient.
+func TestDocsExamples(t *testing.T) {
+	yamlBlock := regexp.MustCompile("(?s)```ya?ml\n(.*?)```")
+	files, err := filepath.Glob("../../docs/user/*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	platformFiles, err := filepath.Glob("../../docs/user/*/*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range append(files, platformFiles...) {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, match := range yamlBlock.FindAllSubmatch(data, -1) {
+			var config map[string]interface{}
+			if err := yaml.Unmarshal(match[1], &config); err != nil {
+				t.Errorf("%s: %v", file, err)
+				continue
+			}
+			_, unknown := ValidateSchema(config)
+			for _, err := range unknown {
+				t.Errorf("%s: %v", file, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Package bootstrap gathers the journals of the bootstrap node through
+// systemd-journal-gatewayd.
+package bootstrap
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/terraform"
+	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/types/libvirt"
+	"github.com/openshift/installer/pkg/types/openstack"
+)
+
+const (
+	// journalGatewayPort is the port systemd-journal-gatewayd.socket
+	// listens on.
+	journalGatewayPort = "19531"
+)
+
+var (
+	// Units are the systemd units whose journals are gathered.
+	Units = []string{
+		"bootkube.service",
+		"kubelet.service",
+		"openshift.service",
+	}
+
+	// bootstrapResources are the Terraform resources for the bootstrap
+	// node and the attribute holding its address, by platform.
+	bootstrapResources = map[string]struct {
+		address   string
+		attribute string
+	}{
+		aws.Name:       {address: "aws_instance.bootstrap", attribute: "public_ip"},
+		libvirt.Name:   {address: "libvirt_domain.bootstrap", attribute: "network_interface.0.addresses.0"},
+		openstack.Name: {address: "openstack_compute_instance_v2.bootstrap", attribute: "access_ip_v4"},
+	}
+)
+
+// Host returns the address of the bootstrap node, as recorded in the
+// Terraform state in the asset directory.
+func Host(dir string) (string, error) {
+	metadata, err := cluster.LoadMetadata(dir)
+	if err != nil {
+		return "", err
+	}
+
+	platform := metadata.Platform()
+	resource, ok := bootstrapResources[platform]
+	if !ok {
+		return "", errors.Errorf("cannot find the bootstrap node on platform %q", platform)
+	}
+
+	attributes, err := terraform.LookupResource(filepath.Join(dir, terraform.StateFileName), []string{"root", "bootstrap"}, resource.address)
+	if err != nil {
+		return "", err
+	}
+
+	host := attributes[resource.attribute]
+	if host == "" {
+		return "", errors.Errorf("%s has no %s", resource.address, resource.attribute)
+	}
+	return host, nil
+}
+
+// Gather downloads the journals of Units from the journal gateway on host,
+// authenticating with the given client certificate and key and trusting
+// only servers signed by rootCA.  The journals are written into a gzipped
+// tarball at the given path, which is removed if any of them cannot be
+// gathered.
+func Gather(host string, clientCert, clientKey, rootCA []byte, tarball string) error {
+	client, err := newClient(clientCert, clientKey, rootCA)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(tarball, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if err := writeJournals(file, client, host); err != nil {
+		file.Close()
+		os.Remove(tarball)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tarball)
+		return errors.Wrapf(err, "failed to close %s", tarball)
+	}
+	return nil
+}
+
+// writeJournals writes the journals of Units, gathered from host, to w as a
+// gzipped tarball.
+func writeJournals(w io.Writer, client *http.Client, host string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	now := time.Now()
+	for _, unit := range Units {
+		logrus.Infof("Gathering the journal of %s from %s...", unit, host)
+		data, err := fetchJournal(client, host, unit)
+		if err != nil {
+			return errors.Wrapf(err, "failed to gather the journal of %s", unit)
+		}
+
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    path.Join("bootstrap", "journals", unit+".log"),
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "failed to finish the tarball")
+	}
+	return errors.Wrap(gz.Close(), "failed to finish the gzip stream")
+}
+
+// newClient returns an HTTP client that authenticates with the journal
+// gateway using mutual TLS.  The gateway's certificate has no SANs for the
+// node's address, so its chain is verified against rootCA without checking
+// the host name.
+func newClient(clientCert, clientKey, rootCA []byte) (*http.Client, error) {
+	cert, err := cryptotls.X509KeyPair(clientCert, clientKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the journal-gatewayd client certificate")
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootCA) {
+		return nil, errors.New("failed to load the root CA")
+	}
+
+	return &http.Client{
+		Timeout: 5 * time.Minute,
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
+			TLSClientConfig: &cryptotls.Config{
+				Certificates:       []cryptotls.Certificate{cert},
+				InsecureSkipVerify: true,
+				VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+					if len(rawCerts) == 0 {
+						return errors.New("journal gateway presented no certificate")
+					}
+					certs := make([]*x509.Certificate, 0, len(rawCerts))
+					for _, raw := range rawCerts {
+						c, err := x509.ParseCertificate(raw)
+						if err != nil {
+							return err
+						}
+						certs = append(certs, c)
+					}
+					intermediates := x509.NewCertPool()
+					for _, c := range certs[1:] {
+						intermediates.AddCert(c)
+					}
+					_, err := certs[0].Verify(x509.VerifyOptions{
+						Roots:         roots,
+						Intermediates: intermediates,
+						KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+					})
+					return err
+				},
+			},
+		},
+	}, nil
+}
+
+// fetchJournal returns the journal of the given unit as plain text.
+func fetchJournal(client *http.Client, host string, unit string) ([]byte, error) {
+	u := &url.URL{
+		Scheme:   "https",
+		Host:     net.JoinHostPort(host, journalGatewayPort),
+		Path:     "/entries",
+		RawQuery: url.Values{"_SYSTEMD_UNIT": []string{unit}}.Encode(),
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected response from %s: %s", u, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
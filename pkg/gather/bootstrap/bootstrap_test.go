@@ -0,0 +1,105 @@
+package bootstrap
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testState returns a Terraform state with the given bootstrap resource in
+// the bootstrap module.
+func testState(address string, attributes string) string {
+	return `{
+    "version": 3,
+    "modules": [
+        {
+            "path": ["root", "bootstrap"],
+            "resources": {
+                "` + address + `": {
+                    "primary": {
+                        "attributes": ` + attributes + `
+                    }
+                }
+            }
+        }
+    ]
+}`
+}
+
+func TestHost(t *testing.T) {
+	cases := []struct {
+		name          string
+		metadata      string
+		state         string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "aws",
+			metadata: `{"clusterName": "test", "aws": {"region": "us-east-1"}}`,
+			state:    testState("aws_instance.bootstrap", `{"private_ip": "10.0.0.5", "public_ip": "192.0.2.1"}`),
+			expected: "192.0.2.1",
+		},
+		{
+			name:     "libvirt",
+			metadata: `{"clusterName": "test", "libvirt": {"uri": "qemu:///system"}}`,
+			state:    testState("libvirt_domain.bootstrap", `{"network_interface.#": "1", "network_interface.0.addresses.#": "1", "network_interface.0.addresses.0": "192.168.126.10"}`),
+			expected: "192.168.126.10",
+		},
+		{
+			name:     "openstack",
+			metadata: `{"clusterName": "test", "openstack": {"cloud": "openstack"}}`,
+			state:    testState("openstack_compute_instance_v2.bootstrap", `{"access_ip_v4": "10.0.0.6"}`),
+			expected: "10.0.0.6",
+		},
+		{
+			name:          "no address",
+			metadata:      `{"clusterName": "test", "aws": {"region": "us-east-1"}}`,
+			state:         testState("aws_instance.bootstrap", `{"private_ip": "10.0.0.5"}`),
+			expectedError: `^aws_instance\.bootstrap has no public_ip$`,
+		},
+		{
+			name:          "no bootstrap resource",
+			metadata:      `{"clusterName": "test", "aws": {"region": "us-east-1"}}`,
+			state:         testState("aws_instance.master", `{"public_ip": "192.0.2.1"}`),
+			expectedError: `^resource "aws_instance\.bootstrap" not found in ".*terraform\.tfstate"$`,
+		},
+		{
+			name:          "no platform",
+			metadata:      `{"clusterName": "test"}`,
+			expectedError: `^cannot find the bootstrap node on platform ""$`,
+		},
+		{
+			name:          "no metadata",
+			expectedError: `metadata\.json: no such file or directory$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "TestHost")
+			if err != nil {
+				t.Fatalf("failed to create temporary directory: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			for name, data := range map[string]string{"metadata.json": tc.metadata, "terraform.tfstate": tc.state} {
+				if data == "" {
+					continue
+				}
+				if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0600); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+
+			host, err := Host(dir)
+			if tc.expectedError != "" {
+				assert.Regexp(t, tc.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, host)
+		})
+	}
+}
@@ -60,7 +60,8 @@ func (ipnet *IPNet) UnmarshalJSON(b []byte) (err error) {
 	return nil
 }
 
-// ParseCIDR parses a CIDR from its string representation.
+// ParseCIDR parses an IPv4 or IPv6 CIDR from its string representation.
+// IPv4 addresses are 4 bytes long and IPv6 addresses 16 bytes long.
 func ParseCIDR(s string) (*IPNet, error) {
 	ip, cidr, err := net.ParseCIDR(s)
 	if err != nil {
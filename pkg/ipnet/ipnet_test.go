@@ -37,6 +37,7 @@ func TestUnmarshal(t *testing.T) {
 			IP:   net.IP{192, 168, 0, 10},
 			Mask: net.IPv4Mask(255, 255, 255, 0),
 		}},
+		MustParseCIDR("fd01::/48"),
 	} {
 		t.Run(ipNetIn.String(), func(t *testing.T) {
 			data, err := json.Marshal(ipNetIn)
@@ -56,3 +57,26 @@ func TestUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCIDR(t *testing.T) {
+	for _, tc := range []struct {
+		cidr     string
+		ipLength int
+	}{
+		{cidr: "10.0.0.0/16", ipLength: net.IPv4len},
+		{cidr: "fd01::/48", ipLength: net.IPv6len},
+	} {
+		t.Run(tc.cidr, func(t *testing.T) {
+			ipNet, err := ParseCIDR(tc.cidr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ipNet.String() != tc.cidr {
+				t.Fatalf("%v != %v", ipNet, tc.cidr)
+			}
+			if len(ipNet.IP) != tc.ipLength {
+				t.Fatalf("%d != %d", len(ipNet.IP), tc.ipLength)
+			}
+		})
+	}
+}
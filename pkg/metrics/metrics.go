@@ -0,0 +1,186 @@
+// Package metrics records how long the phases of the installation take.
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phase identifies a timed part of the installation.
+type Phase string
+
+const (
+	// AssetFetch is the time to fetch an asset, including fetching its
+	// dependencies and generating it.
+	AssetFetch Phase = "asset-fetch"
+
+	// AssetGenerate is the time to generate an asset, once its
+	// dependencies have been fetched.
+	AssetGenerate Phase = "asset-generate"
+
+	// TerraformApply is the time for 'terraform apply'.
+	TerraformApply Phase = "terraform-apply"
+
+	// APIWait is the time waiting for the Kubernetes API to come up.
+	APIWait Phase = "api-wait"
+
+	// BootstrapWait is the time waiting for the bootstrap-complete event.
+	BootstrapWait Phase = "bootstrap-wait"
+
+	// BootstrapDestroy is the time to destroy the bootstrap resources.
+	BootstrapDestroy Phase = "bootstrap-destroy"
+
+	// ClusterInitWait is the time waiting for the cluster to initialize.
+	ClusterInitWait Phase = "cluster-init-wait"
+)
+
+const (
+	// ReportFileName is the name of the JSON report in the asset directory.
+	ReportFileName = ".openshift_install_metrics.json"
+
+	textfileMetric = "openshift_install_phase_duration_seconds"
+)
+
+// Timing is how long a phase took.
+type Timing struct {
+	// Phase is the timed phase.
+	Phase Phase `json:"phase"`
+
+	// Name distinguishes timings of the same phase, e.g. the name of the
+	// asset for AssetGenerate.  It is empty for phases that only happen
+	// once.
+	Name string `json:"name,omitempty"`
+
+	// Start is when the phase started.
+	Start time.Time `json:"start"`
+
+	// Seconds is how long the phase took.
+	Seconds float64 `json:"seconds"`
+
+	// Failed is true if the phase did not complete successfully.
+	Failed bool `json:"failed,omitempty"`
+}
+
+// Report is the JSON report of a single installer invocation.
+type Report struct {
+	// Version is the version of the installer.
+	Version string `json:"version"`
+
+	// Command is the installer command that was run, e.g.
+	// "create cluster".
+	Command string `json:"command"`
+
+	// Timings are the timings of the phases, in the order they ended.
+	Timings []Timing `json:"timings"`
+}
+
+var (
+	mu      sync.Mutex
+	timings []Timing
+	now     = time.Now
+)
+
+// Start starts timing a phase.  The returned function records the timing
+// when it is called, and is meant to be deferred.  It takes a pointer to
+// the error returned by the phase, which may be nil, to mark the timing as
+// failed.
+func Start(phase Phase, name string) func(err *error) {
+	start := now()
+	return func(err *error) {
+		end := now()
+		mu.Lock()
+		defer mu.Unlock()
+		timings = append(timings, Timing{
+			Phase:   phase,
+			Name:    name,
+			Start:   start.UTC(),
+			Seconds: end.Sub(start).Seconds(),
+			Failed:  err != nil && *err != nil,
+		})
+	}
+}
+
+// Timings returns the timings recorded so far.
+func Timings() []Timing {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Timing(nil), timings...)
+}
+
+// WriteReport writes the timings recorded so far as a JSON report into
+// the given directory, replacing any earlier report.
+func WriteReport(dir string, version string, command string) error {
+	data, err := json.MarshalIndent(&Report{
+		Version: version,
+		Command: command,
+		Timings: Timings(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, ReportFileName), append(data, '\n'))
+}
+
+// WriteTextfile writes the timings recorded so far to the given path, in
+// the format of the Prometheus node exporter's textfile collector.  The
+// durations of repeated phases with the same name are summed.
+func WriteTextfile(path string, version string) error {
+	type key struct {
+		phase Phase
+		name  string
+	}
+	sums := map[key]float64{}
+	var keys []key
+	for _, t := range Timings() {
+		k := key{phase: t.Phase, name: t.Name}
+		if _, ok := sums[k]; !ok {
+			keys = append(keys, k)
+		}
+		sums[k] += t.Seconds
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].phase != keys[j].phase {
+			return keys[i].phase < keys[j].phase
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "# HELP %s Time spent in each phase of the installation.\n", textfileMetric)
+	fmt.Fprintf(buf, "# TYPE %s gauge\n", textfileMetric)
+	for _, k := range keys {
+		fmt.Fprintf(buf, "%s{phase=%s,name=%s,version=%s} %g\n",
+			textfileMetric, quoteLabel(string(k.phase)), quoteLabel(k.name), quoteLabel(version), sums[k])
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// quoteLabel quotes a Prometheus label value.
+func quoteLabel(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `"`, `\"`, -1)
+	value = strings.Replace(value, "\n", `\n`, -1)
+	return `"` + value + `"`
+}
+
+// writeFileAtomic writes the file through a temporary file, so that
+// readers like the textfile collector never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	tempPath := path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
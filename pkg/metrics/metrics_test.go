@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock advances by one second every time it is read.
+func fakeClock() func() time.Time {
+	t := time.Date(2019, 2, 1, 12, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		t = t.Add(time.Second)
+		return t
+	}
+}
+
+func TestStart(t *testing.T) {
+	defer func() {
+		now = time.Now
+		timings = nil
+	}()
+	now = fakeClock()
+	timings = nil
+
+	func() (err error) {
+		defer Start(AssetGenerate, "Install Config")(&err)
+		return nil
+	}()
+	func() (err error) {
+		defer Start(TerraformApply, "aws")(&err)
+		return errors.New("failed to apply using Terraform")
+	}()
+	Start(APIWait, "")(nil)
+
+	assert.Equal(t, []Timing{
+		{Phase: AssetGenerate, Name: "Install Config", Start: time.Date(2019, 2, 1, 12, 0, 1, 0, time.UTC), Seconds: 1},
+		{Phase: TerraformApply, Name: "aws", Start: time.Date(2019, 2, 1, 12, 0, 3, 0, time.UTC), Seconds: 1, Failed: true},
+		{Phase: APIWait, Start: time.Date(2019, 2, 1, 12, 0, 5, 0, time.UTC), Seconds: 1},
+	}, Timings())
+}
+
+func TestWriteTextfile(t *testing.T) {
+	defer func() { timings = nil }()
+	timings = []Timing{
+		{Phase: AssetGenerate, Name: "Master Ignition Config", Seconds: 0.5},
+		{Phase: TerraformApply, Name: "aws", Seconds: 300},
+		{Phase: AssetGenerate, Name: "Install \"Config\"", Seconds: 1.25},
+		{Phase: TerraformApply, Name: "aws", Seconds: 20},
+	}
+
+	dir, err := ioutil.TempDir("", "openshift-install-metrics-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "openshift_install.prom")
+	if err := WriteTextfile(path, "v0.12.0"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `# HELP openshift_install_phase_duration_seconds Time spent in each phase of the installation.
+# TYPE openshift_install_phase_duration_seconds gauge
+openshift_install_phase_duration_seconds{phase="asset-generate",name="Install \"Config\"",version="v0.12.0"} 1.25
+openshift_install_phase_duration_seconds{phase="asset-generate",name="Master Ignition Config",version="v0.12.0"} 0.5
+openshift_install_phase_duration_seconds{phase="terraform-apply",name="aws",version="v0.12.0"} 320
+`, string(data))
+}
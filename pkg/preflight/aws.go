@@ -0,0 +1,218 @@
+package preflight
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	awsutil "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/asset/machines"
+	awsmachines "github.com/openshift/installer/pkg/asset/machines/aws"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/yamlpos"
+)
+
+// awsClient looks up the AWS resources that the install-config refers to.
+type awsClient interface {
+	// AvailabilityZones returns the available zones of the region.
+	AvailabilityZones() ([]string, error)
+
+	// InstanceTypeOffered returns true if there are reserved instance
+	// offerings for the given type in the zone, which suggests that
+	// instances of the type can be launched there.
+	InstanceTypeOffered(instanceType string, zone string) (bool, error)
+
+	// PublicZone returns an error if there is no public Route53 zone with
+	// the given name.
+	PublicZone(name string) error
+
+	// Subnets returns the subnets with the given IDs, keyed by their IDs.
+	Subnets(ids []string) (map[string]awsutil.Subnet, error)
+}
+
+// realAWSClient is the awsClient for a region, using the AWS credentials of
+// the installer.
+type realAWSClient struct {
+	region string
+	ec2    *ec2.EC2
+}
+
+func newAWSClient(region string) awsClient {
+	return &realAWSClient{region: region}
+}
+
+func (c *realAWSClient) AvailabilityZones() ([]string, error) {
+	return awsmachines.AvailabilityZones(c.region)
+}
+
+// InstanceTypeOffered looks for reserved instance offerings, since the
+// EC2 API has no other way to tell which zones offer an instance type.  A
+// type without offerings may still be available on demand.
+func (c *realAWSClient) InstanceTypeOffered(instanceType string, zone string) (bool, error) {
+	if c.ec2 == nil {
+		ssn, err := awsutil.GetSession()
+		if err != nil {
+			return false, err
+		}
+		c.ec2 = ec2.New(ssn, aws.NewConfig().WithRegion(c.region))
+	}
+	resp, err := c.ec2.DescribeReservedInstancesOfferings(&ec2.DescribeReservedInstancesOfferingsInput{
+		AvailabilityZone:   aws.String(zone),
+		InstanceType:       aws.String(instanceType),
+		ProductDescription: aws.String("Linux/UNIX"),
+		IncludeMarketplace: aws.Bool(false),
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(resp.ReservedInstancesOfferings) > 0, nil
+}
+
+func (c *realAWSClient) PublicZone(name string) error {
+	_, err := awsutil.GetPublicZone(name)
+	return err
+}
+
+func (c *realAWSClient) Subnets(ids []string) (map[string]awsutil.Subnet, error) {
+	return awsutil.GetSubnets(c.region, ids)
+}
+
+// awsPool is the zones and instance type of a machine pool, after the
+// platform defaults have been applied.
+type awsPool struct {
+	// zones are the zones the pool was configured with, or nil if the
+	// pool uses the default zones.
+	zones        []string
+	zonesPath    *field.Path
+	instanceType string
+	typePath     *field.Path
+}
+
+// newAWSPool returns the zones and instance type of the pool at fldPath,
+// which are taken from the pool, the default machine platform or the
+// instance type the installer defaults to, in that order.
+func newAWSPool(pool *types.MachinePool, fldPath *field.Path, platform *awstypes.Platform, instanceType string) awsPool {
+	p := awsPool{
+		instanceType: instanceType,
+		typePath:     fldPath.Child("platform", "aws", "type"),
+	}
+	for _, mp := range []struct {
+		pool    *awstypes.MachinePool
+		fldPath *field.Path
+	}{
+		{pool: platform.DefaultMachinePlatform, fldPath: field.NewPath("platform", "aws", "defaultMachinePlatform")},
+		{pool: pool.Platform.AWS, fldPath: fldPath.Child("platform", "aws")},
+	} {
+		if mp.pool == nil {
+			continue
+		}
+		if len(mp.pool.Zones) > 0 {
+			p.zones = mp.pool.Zones
+			p.zonesPath = mp.fldPath.Child("zones")
+		}
+		if mp.pool.InstanceType != "" {
+			p.instanceType = mp.pool.InstanceType
+			p.typePath = mp.fldPath.Child("type")
+		}
+	}
+	return p
+}
+
+// awsPools returns the zones and instance types of the control plane and
+// compute pools.
+func awsPools(config *types.InstallConfig) []awsPool {
+	platform := config.Platform.AWS
+	var pools []awsPool
+	if config.ControlPlane != nil {
+		pools = append(pools, newAWSPool(config.ControlPlane, field.NewPath("controlPlane"), platform, machines.AWSDefaultMasterMachineType(platform.Region)))
+	}
+	for i := range config.Compute {
+		pools = append(pools, newAWSPool(&config.Compute[i], field.NewPath("compute").Index(i), platform, machines.AWSDefaultWorkerMachineType(platform.Region)))
+	}
+	return pools
+}
+
+// checkAWS checks that the zones of the machine pools are available in the
+// region, that their instance types have reserved instance offerings in
+// each of their zones, which is only a warning, that there is a public
+// Route53 zone for the base domain and that the existing subnets, if any,
+// are suitable for the cluster.
+func checkAWS(config *types.InstallConfig, client awsClient, source *yamlpos.File) []Result {
+	// Pools without zones use the zones of the private subnets, if there
+	// are existing subnets, or else all the zones of the region.
+	var subnetsResult *Result
+	var subnetZones []string
+	if ids := config.Platform.AWS.Subnets; len(ids) > 0 {
+		subnetsResult = &Result{Check: "AWS subnets"}
+		subnets, err := client.Subnets(ids)
+		if err != nil {
+			subnetsResult.fail(errors.Wrap(err, "failed to fetch the subnets"))
+		} else {
+			subnetsResult.add(source, awsutil.ValidateSubnets(config, subnets), Fail)
+			subnetZones = awsutil.Zones(awsutil.PrivateSubnets(subnets))
+		}
+	}
+
+	zonesResult := Result{Check: "AWS availability zones"}
+	typesResult := Result{Check: "AWS instance types"}
+	region := config.Platform.AWS.Region
+	regionZones, err := client.AvailabilityZones()
+	if err != nil {
+		err = errors.Wrapf(err, "failed to fetch the availability zones of %s", region)
+		zonesResult.fail(err)
+		typesResult.fail(err)
+	} else if len(regionZones) == 0 {
+		zonesResult.add(source, field.ErrorList{field.Invalid(field.NewPath("platform", "aws", "region"), region, "the region has no available zones")}, Fail)
+	} else {
+		for _, pool := range awsPools(config) {
+			zones := regionZones
+			if subnetZones != nil {
+				zones = subnetZones
+			}
+			if pool.zones != nil {
+				zones = nil
+				for i, zone := range pool.zones {
+					if !contains(regionZones, zone) {
+						zonesResult.add(source, field.ErrorList{field.NotSupported(pool.zonesPath.Index(i), zone, regionZones)}, Fail)
+						continue
+					}
+					zones = append(zones, zone)
+				}
+			}
+			for _, zone := range zones {
+				offered, err := client.InstanceTypeOffered(pool.instanceType, zone)
+				if err != nil {
+					typesResult.fail(errors.Wrapf(err, "failed to check whether %s instances are offered in %s", pool.instanceType, zone))
+				} else if !offered {
+					// Only a warning, because the lack of reserved
+					// instance offerings does not prove that the type
+					// cannot be launched on demand.
+					typesResult.add(source, field.ErrorList{field.Invalid(pool.typePath, pool.instanceType, fmt.Sprintf("there are no reserved instance offerings for the instance type in %s, so it may not be offered there", zone))}, Warn)
+				}
+			}
+		}
+	}
+
+	route53Result := Result{Check: "AWS Route53 zone"}
+	if err := client.PublicZone(config.BaseDomain); err != nil {
+		route53Result.add(source, field.ErrorList{field.Invalid(field.NewPath("baseDomain"), config.BaseDomain, err.Error())}, Fail)
+	}
+	results := []Result{zonesResult, typesResult, route53Result}
+	if subnetsResult != nil {
+		results = append(results, *subnetsResult)
+	}
+	return results
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
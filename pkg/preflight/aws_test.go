@@ -0,0 +1,179 @@
+package preflight
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	awsutil "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/yamlpos"
+)
+
+type fakeAWSClient struct {
+	zones      []string
+	zonesErr   error
+	offered    map[string][]string
+	publicZone string
+	subnets    map[string]awsutil.Subnet
+}
+
+func (c *fakeAWSClient) AvailabilityZones() ([]string, error) {
+	return c.zones, c.zonesErr
+}
+
+func (c *fakeAWSClient) InstanceTypeOffered(instanceType string, zone string) (bool, error) {
+	return contains(c.offered[instanceType], zone), nil
+}
+
+func (c *fakeAWSClient) PublicZone(name string) error {
+	if name != c.publicZone {
+		return errors.Errorf("No public route53 zone found matching name %q", name)
+	}
+	return nil
+}
+
+func (c *fakeAWSClient) Subnets(ids []string) (map[string]awsutil.Subnet, error) {
+	return c.subnets, nil
+}
+
+const awsConfig = `baseDomain: example.com
+controlPlane:
+  name: master
+  platform:
+    aws:
+      zones: [us-east-1a, us-east-1z]
+compute:
+- name: worker
+  platform:
+    aws:
+      type: c5.large
+platform:
+  aws:
+    region: us-east-1
+`
+
+func TestCheckAWS(t *testing.T) {
+	config := &types.InstallConfig{
+		BaseDomain: "example.com",
+		ControlPlane: &types.MachinePool{
+			Name: "master",
+			Platform: types.MachinePoolPlatform{
+				AWS: &awstypes.MachinePool{Zones: []string{"us-east-1a", "us-east-1z"}},
+			},
+		},
+		Compute: []types.MachinePool{{
+			Name: "worker",
+			Platform: types.MachinePoolPlatform{
+				AWS: &awstypes.MachinePool{InstanceType: "c5.large"},
+			},
+		}},
+		Platform: types.Platform{
+			AWS: &awstypes.Platform{Region: "us-east-1"},
+		},
+	}
+	source := yamlpos.Parse("install-config.yaml", []byte(awsConfig))
+
+	cases := []struct {
+		name     string
+		client   *fakeAWSClient
+		expected []Result
+	}{
+		{
+			name: "valid",
+			client: &fakeAWSClient{
+				zones: []string{"us-east-1a", "us-east-1b", "us-east-1z"},
+				offered: map[string][]string{
+					"m4.xlarge": {"us-east-1a", "us-east-1z"},
+					"c5.large":  {"us-east-1a", "us-east-1b", "us-east-1z"},
+				},
+				publicZone: "example.com",
+			},
+			expected: []Result{
+				{Check: "AWS availability zones", Status: Pass},
+				{Check: "AWS instance types", Status: Pass},
+				{Check: "AWS Route53 zone", Status: Pass},
+			},
+		},
+		{
+			name: "invalid",
+			client: &fakeAWSClient{
+				zones: []string{"us-east-1a", "us-east-1b"},
+				offered: map[string][]string{
+					"m4.xlarge": {"us-east-1a"},
+					"c5.large":  {"us-east-1a"},
+				},
+			},
+			expected: []Result{
+				{Check: "AWS availability zones", Status: Fail, Messages: []string{
+					`install-config.yaml:6:7: controlPlane.platform.aws.zones[1]: Unsupported value: "us-east-1z": supported values: "us-east-1a", "us-east-1b"`,
+				}},
+				{Check: "AWS instance types", Status: Warn, Messages: []string{
+					`install-config.yaml:11:7: compute[0].platform.aws.type: there are no reserved instance offerings for the instance type in us-east-1b, so it may not be offered there`,
+				}},
+				{Check: "AWS Route53 zone", Status: Fail, Messages: []string{
+					`install-config.yaml:1:1: baseDomain: Invalid value: "example.com": No public route53 zone found matching name "example.com"`,
+				}},
+			},
+		},
+		{
+			name: "zones unavailable",
+			client: &fakeAWSClient{
+				zonesErr:   errors.New("no credentials"),
+				publicZone: "example.com",
+			},
+			expected: []Result{
+				{Check: "AWS availability zones", Status: Fail, Messages: []string{"failed to fetch the availability zones of us-east-1: no credentials"}},
+				{Check: "AWS instance types", Status: Fail, Messages: []string{"failed to fetch the availability zones of us-east-1: no credentials"}},
+				{Check: "AWS Route53 zone", Status: Pass},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, checkAWS(config, tc.client, source))
+		})
+	}
+}
+
+func TestCheckAWSSubnets(t *testing.T) {
+	config := &types.InstallConfig{
+		BaseDomain: "example.com",
+		Networking: &types.Networking{
+			MachineCIDR: ipnet.MustParseCIDR("10.0.0.0/16"),
+		},
+		ControlPlane: &types.MachinePool{Name: "master"},
+		Platform: types.Platform{
+			AWS: &awstypes.Platform{
+				Region:  "us-east-1",
+				Subnets: []string{"subnet-private", "subnet-other"},
+			},
+		},
+	}
+	source := yamlpos.Parse("install-config.yaml", []byte(`platform:
+  aws:
+    region: us-east-1
+    subnets:
+    - subnet-private
+    - subnet-other
+`))
+	client := &fakeAWSClient{
+		zones:      []string{"us-east-1a"},
+		publicZone: "example.com",
+		subnets: map[string]awsutil.Subnet{
+			"subnet-private": {ID: "subnet-private", VPC: "vpc-1", Zone: "us-east-1a", CIDR: "10.0.0.0/20", NAT: true},
+			"subnet-other":   {ID: "subnet-other", VPC: "vpc-2", Zone: "us-east-1a", CIDR: "10.0.16.0/20", Public: true},
+		},
+	}
+
+	results := checkAWS(config, client, source)
+	if !assert.Len(t, results, 4) {
+		return
+	}
+	assert.Equal(t, Result{Check: "AWS subnets", Status: Fail, Messages: []string{
+		`install-config.yaml:6:5: platform.aws.subnets[1]: Invalid value: "subnet-other": subnet is in vpc-2, but subnet-private is in vpc-1`,
+	}}, results[3])
+}
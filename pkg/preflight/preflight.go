@@ -0,0 +1,150 @@
+// Package preflight checks an install-config before a cluster is created,
+// reporting every problem it finds instead of stopping at the first one.
+package preflight
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/explain"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/conversion"
+	"github.com/openshift/installer/pkg/types/defaults"
+	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
+	"github.com/openshift/installer/pkg/types/validation"
+	"github.com/openshift/installer/pkg/yamlpos"
+)
+
+// Status is the outcome of a check.
+type Status int
+
+const (
+	// Pass means that the check found no problems.
+	Pass Status = iota
+	// Warn means that the check found problems that do not prevent the
+	// installation, but are likely to be mistakes.
+	Warn
+	// Fail means that the check found problems that prevent the
+	// installation, or that it could not be run.
+	Fail
+)
+
+// String returns the lowercase name of the status.
+func (s Status) String() string {
+	switch s {
+	case Pass:
+		return "pass"
+	case Warn:
+		return "warn"
+	case Fail:
+		return "fail"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// Result is the outcome of a check and the problems it found.
+type Result struct {
+	Check    string
+	Status   Status
+	Messages []string
+}
+
+// add records errs as problems of the given status, located in source.
+func (r *Result) add(source *yamlpos.File, errs field.ErrorList, status Status) {
+	for _, err := range errs {
+		if status == Warn {
+			r.Messages = append(r.Messages, fmt.Sprintf("%s: %s: %s", source.Locate(err.Field), err.Field, err.Detail))
+		} else {
+			r.Messages = append(r.Messages, fmt.Sprintf("%s: %v", source.Locate(err.Field), err))
+		}
+		if status > r.Status {
+			r.Status = status
+		}
+	}
+}
+
+// fail records an error that is not about a field, usually because the check
+// could not be run.
+func (r *Result) fail(err error) {
+	r.Messages = append(r.Messages, err.Error())
+	r.Status = Fail
+}
+
+// newResult returns the result of a check that found errs, which are
+// problems of the given status.
+func newResult(check string, source *yamlpos.File, errs field.ErrorList, status Status) Result {
+	r := Result{Check: check}
+	r.add(source, errs, status)
+	return r
+}
+
+// Failed returns true if any of the results is a failure.
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Status == Fail {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the install-config in data, which was read from the file
+// with the given name.  The offline checks are the ones that the installer
+// runs when it loads the install-config, plus warnings.  If online is true,
+// the platform is also checked against the cloud: on AWS, the availability
+// zones, instance types, Route53 zone and existing subnets; on OpenStack,
+// the cloud, region, network and flavor.  If lenient is true, fields that
+// are not in the install-config schema are warnings instead of failures.
+func Validate(filename string, data []byte, online bool, lenient bool) []Result {
+	source := yamlpos.Parse(filename, data)
+
+	schema := Result{Check: "Schema"}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		schema.fail(errors.Wrapf(err, "%s: failed to unmarshal", filename))
+		return []Result{schema}
+	}
+	unknownStatus := Fail
+	if lenient {
+		unknownStatus = Warn
+	}
+	allErrs, unknown := explain.ValidateSchema(raw)
+	schema.add(source, unknown, unknownStatus)
+	schema.add(source, allErrs, Fail)
+	results := []Result{schema}
+	if schema.Status == Fail {
+		return results
+	}
+
+	valid := Result{Check: "Install config"}
+	config := &types.InstallConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		valid.fail(errors.Wrapf(err, "%s: failed to unmarshal", filename))
+		return append(results, valid)
+	}
+	if err := conversion.ConvertInstallConfig(config); err != nil {
+		valid.fail(errors.Wrapf(err, "%s: failed to upconvert", filename))
+		return append(results, valid)
+	}
+	defaults.SetInstallConfigDefaults(config)
+
+	// The OpenStack values are only fetched from the cloud when online.
+	valid.add(source, validation.ValidateInstallConfig(config, nil), Fail)
+	results = append(results, valid, newResult("Warnings", source, validation.WarnInstallConfig(config), Warn))
+	if !online || valid.Status == Fail {
+		return results
+	}
+
+	switch {
+	case config.Platform.AWS != nil:
+		results = append(results, checkAWS(config, newAWSClient(config.Platform.AWS.Region), source)...)
+	case config.Platform.OpenStack != nil:
+		fldPath := field.NewPath("platform", "openstack")
+		results = append(results, newResult("OpenStack cloud", source, openstackvalidation.ValidatePlatform(config.Platform.OpenStack, fldPath, openstackvalidation.NewValidValuesFetcher()), Fail))
+	}
+	return results
+}
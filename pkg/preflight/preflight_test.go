@@ -0,0 +1,124 @@
+package preflight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   string
+		lenient  bool
+		expected []Result
+	}{
+		{
+			name: "valid",
+			config: `apiVersion: v1beta4
+baseDomain: example.com
+metadata:
+  name: test
+compute:
+- name: worker
+  replicas: 3
+platform:
+  none: {}
+pullSecret: '{"auths":{"example.com":{"auth":"authorization value"}}}'
+`,
+			expected: []Result{
+				{Check: "Schema", Status: Pass},
+				{Check: "Install config", Status: Pass},
+				{Check: "Warnings", Status: Pass},
+			},
+		},
+		{
+			name:   "malformed",
+			config: "baseDomain: [",
+			expected: []Result{
+				{Check: "Schema", Status: Fail, Messages: []string{"install-config.yaml: failed to unmarshal: error converting YAML to JSON: yaml: line 1: did not find expected node content"}},
+			},
+		},
+		{
+			name: "unknown field",
+			config: `apiVersion: v1beta4
+baseDomain: example.com
+metadata:
+  name: test
+compute:
+- name: worker
+  replicas: 3
+platform:
+  none: {}
+pullSecret: '{"auths":{"example.com":{"auth":"authorization value"}}}'
+sshKeys: ssh-rsa AAAA
+`,
+			expected: []Result{
+				{Check: "Schema", Status: Fail, Messages: []string{`install-config.yaml:11:1: sshKeys: Forbidden: unknown field, did you mean "sshKey"?`}},
+			},
+		},
+		{
+			name: "lenient unknown field",
+			config: `apiVersion: v1beta4
+baseDomain: example.com
+metadata:
+  name: test
+compute:
+- name: worker
+  replicas: 3
+platform:
+  none: {}
+pullSecret: '{"auths":{"example.com":{"auth":"authorization value"}}}'
+sshKeys: ssh-rsa AAAA
+`,
+			lenient: true,
+			expected: []Result{
+				{Check: "Schema", Status: Warn, Messages: []string{`install-config.yaml:11:1: sshKeys: unknown field, did you mean "sshKey"?`}},
+				{Check: "Install config", Status: Pass},
+				{Check: "Warnings", Status: Pass},
+			},
+		},
+		{
+			name: "invalid and warnings",
+			config: `apiVersion: v1beta4
+baseDomain: example.com
+metadata:
+  name: test
+controlPlane:
+  name: master
+  replicas: 4
+compute:
+- name: worker
+  replicas: 0
+networking:
+  clusterNetwork:
+  - cidr: 10.128.0.0/14
+    hostPrefix: 33
+platform:
+  none: {}
+pullSecret: '{"auths":{"example.com":{"auth":"authorization value"}}}'
+`,
+			expected: []Result{
+				{Check: "Schema", Status: Pass},
+				{Check: "Install config", Status: Fail, Messages: []string{
+					"install-config.yaml:14:5: networking.clusterNetwork[0].hostPrefix: Invalid value: 33: hostPrefix must not be larger than 32 for an IPv4 cluster network",
+				}},
+				{Check: "Warnings", Status: Warn, Messages: []string{
+					"install-config.yaml:7:3: controlPlane.replicas: etcd tolerates as many member failures with 4 control plane replicas as with 3",
+					"install-config.yaml:8:1: compute: there are no compute replicas; the cluster will not fully initialize without compute nodes",
+				}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			results := Validate("install-config.yaml", []byte(tc.config), false, tc.lenient)
+			assert.Equal(t, tc.expected, results)
+		})
+	}
+}
+
+func TestFailed(t *testing.T) {
+	assert.False(t, Failed([]Result{{Status: Pass}, {Status: Warn}}))
+	assert.True(t, Failed([]Result{{Status: Pass}, {Status: Fail}}))
+}
@@ -0,0 +1,135 @@
+// Package redact masks secrets in text, such as logs that may be shared.
+package redact
+
+import (
+	"encoding/base64"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mask replaces the redacted secrets.
+const Mask = "REDACTED"
+
+// minSecretLength is the length below which values are not registered as
+// secrets, because masking them would mangle unrelated text.
+const minSecretLength = 8
+
+// pattern is a regular expression matching a secret, and the replacement
+// for the match.
+type pattern struct {
+	regexp      *regexp.Regexp
+	replacement string
+}
+
+var (
+	// patterns match secrets that are not known in advance.  The parts of
+	// the match around the secret itself are kept, so that it is still
+	// clear what was redacted.  JSON embedded in JSON strings has escaped
+	// quotes, so quotes may be preceded by backslashes.
+	patterns = []pattern{
+		{
+			// PEM-encoded private keys, up to the end of the text if the
+			// end of the key is missing.
+			regexp:      regexp.MustCompile(`(?s)-----BEGIN ([A-Z ]*)PRIVATE KEY-----(?:.*?-----END [A-Z ]*PRIVATE KEY-----|.*)`),
+			replacement: "-----BEGIN ${1}PRIVATE KEY-----" + Mask + "-----END ${1}PRIVATE KEY-----",
+		},
+		{
+			// Data URLs, which carry the files of Ignition configs such as
+			// the pull secret.
+			regexp:      regexp.MustCompile(`(data:[^,\s"\\]*;base64,)[A-Za-z0-9+/=]+`),
+			replacement: "${1}" + Mask,
+		},
+		{
+			// The auths of pull secrets.
+			regexp:      regexp.MustCompile(`(\\*"auth\\*"\s*:\s*\\*")[^"\\]*`),
+			replacement: "${1}" + Mask,
+		},
+		{
+			// AWS secret access keys.
+			regexp:      regexp.MustCompile(`(?i)((?:aws_)?secret_?access_?key\\*"?\s*[:=]\s*\\*"?)[A-Za-z0-9/+=]{40}`),
+			replacement: "${1}" + Mask,
+		},
+		{
+			// Passwords in the userinfo of URLs, e.g. of proxies, which
+			// are masked whatever their length.
+			regexp:      regexp.MustCompile(`(\b[A-Za-z][A-Za-z0-9+.-]*://[^\s/:@"\\]*:)[^\s/@"\\]+@`),
+			replacement: "${1}" + Mask + "@",
+		},
+		{
+			// Generic credentials, e.g. "password: xyz" or "token=xyz".
+			regexp:      regexp.MustCompile(`(?i)(\b(?:password|passwd|secret|token|api_?key)\\*"?\s*[:=]\s*\\*"?)[^\s"\\,;&]+`),
+			replacement: "${1}" + Mask,
+		},
+	}
+
+	mu      sync.RWMutex
+	secrets = map[string]struct{}{}
+
+	// sortedSecrets are the secrets, longest first, so that a secret
+	// containing another one is masked as a whole.
+	sortedSecrets []string
+)
+
+// AddSecret registers a secret value to be masked wherever it appears,
+// along with its base64 encoding.  Values that are too short to be
+// masked safely are ignored.
+func AddSecret(value string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, v := range []string{value, base64.StdEncoding.EncodeToString([]byte(value))} {
+		if len(v) < minSecretLength {
+			continue
+		}
+		if _, ok := secrets[v]; ok {
+			continue
+		}
+		secrets[v] = struct{}{}
+		sortedSecrets = append(sortedSecrets, v)
+	}
+	sort.SliceStable(sortedSecrets, func(i, j int) bool {
+		return len(sortedSecrets[i]) > len(sortedSecrets[j])
+	})
+}
+
+// String returns s with the registered secrets and anything that looks
+// like a credential masked.
+func String(s string) string {
+	mu.RLock()
+	for _, secret := range sortedSecrets {
+		s = strings.Replace(s, secret, Mask, -1)
+	}
+	mu.RUnlock()
+
+	for _, p := range patterns {
+		s = p.regexp.ReplaceAllString(s, p.replacement)
+	}
+	return s
+}
+
+// URL returns rawurl without its user name and password, for URLs such as
+// those of proxies that may carry credentials.  URLs that do not parse are
+// cut after the last "@" before any query, so that the credentials are
+// dropped even if they are not escaped.
+func URL(rawurl string) string {
+	if u, err := url.Parse(rawurl); err == nil && u.User != nil {
+		u.User = nil
+		return u.String()
+	}
+	prefix, rest := "", rawurl
+	if i := strings.Index(rawurl, "://"); i >= 0 {
+		prefix, rest = rawurl[:i+3], rawurl[i+3:]
+	}
+	end := len(rest)
+	if i := strings.IndexAny(rest, "?#"); i >= 0 {
+		end = i
+	}
+	at := strings.LastIndex(rest[:end], "@")
+	if at < 0 {
+		return rawurl
+	}
+	return prefix + rest[at+1:]
+}
@@ -0,0 +1,37 @@
+package terraform
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/openshift/installer/pkg/events"
+)
+
+// creationCompleteRegexp matches the line Terraform prints when it has
+// created a resource, e.g.:
+//
+//   module.bootstrap.aws_instance.bootstrap: Creation complete after 12s (ID: i-0123456789abcdef0)
+var creationCompleteRegexp = regexp.MustCompile(`^(\S+): Creation complete after (\S+) \(ID: (.*)\)$`)
+
+// parseResourceCreated returns the properties of the
+// TerraformResourceCreated event for a line of 'terraform apply' output,
+// or nil if the line does not report a created resource.
+func parseResourceCreated(line string) map[string]string {
+	match := creationCompleteRegexp.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return nil
+	}
+	return map[string]string{
+		"address":  match[1],
+		"duration": match[2],
+		"id":       match[3],
+	}
+}
+
+// emitResourceCreated emits a TerraformResourceCreated event if the line
+// of 'terraform apply' output reports a created resource.
+func emitResourceCreated(line string) {
+	if data := parseResourceCreated(line); data != nil {
+		events.Emit(events.TerraformResourceCreated, data)
+	}
+}
@@ -0,0 +1,47 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResourceCreated(t *testing.T) {
+	cases := []struct {
+		name     string
+		line     string
+		expected map[string]string
+	}{
+		{
+			name: "module resource",
+			line: "module.bootstrap.aws_instance.bootstrap: Creation complete after 12s (ID: i-0123456789abcdef0)\n",
+			expected: map[string]string{
+				"address":  "module.bootstrap.aws_instance.bootstrap",
+				"duration": "12s",
+				"id":       "i-0123456789abcdef0",
+			},
+		},
+		{
+			name: "counted resource",
+			line: "aws_subnet.private_subnet.2: Creation complete after 1m2s (ID: subnet-0a1b2c3d)",
+			expected: map[string]string{
+				"address":  "aws_subnet.private_subnet.2",
+				"duration": "1m2s",
+				"id":       "subnet-0a1b2c3d",
+			},
+		},
+		{
+			name: "still creating",
+			line: "aws_instance.master.0: Still creating... (10s elapsed)",
+		},
+		{
+			name: "destruction",
+			line: "aws_instance.bootstrap: Destruction complete after 1m0s",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseResourceCreated(tc.line))
+		})
+	}
+}
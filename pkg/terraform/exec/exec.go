@@ -13,7 +13,9 @@ import (
 	"github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/logutils"
 	"github.com/hashicorp/terraform/command"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/logging"
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/cli"
 )
 
@@ -29,6 +31,9 @@ var commands = map[string]cmdFunc{
 	"init": func(meta command.Meta) cli.Command {
 		return &command.InitCommand{Meta: meta}
 	},
+	"plan": func(meta command.Meta) cli.Command {
+		return &command.PlanCommand{Meta: meta}
+	},
 }
 
 func runner(cmd string, dir string, args []string, stdout, stderr io.Writer) int {
@@ -104,6 +109,50 @@ func Init(datadir string, args []string, stdout, stderr io.Writer) int {
 	return runner("init", datadir, args, stdout, stderr)
 }
 
+// Plan is wrapper around `terraform plan` subcommand.
+func Plan(datadir string, args []string, stdout, stderr io.Writer) int {
+	return runner("plan", datadir, args, stdout, stderr)
+}
+
+// PlannedCreates reads the plan file written by `terraform plan -out` and
+// returns the number of managed resources the plan would create, keyed by
+// resource type.
+func PlannedCreates(planFile string) (map[string]int, error) {
+	f, err := os.Open(planFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	plan, err := terraform.ReadPlan(f)
+	if err != nil {
+		return nil, err
+	}
+
+	creates := map[string]int{}
+	if plan.Diff == nil {
+		return creates, nil
+	}
+	for _, module := range plan.Diff.Modules {
+		for key, diff := range module.Resources {
+			switch diff.ChangeType() {
+			case terraform.DiffCreate, terraform.DiffDestroyCreate:
+			default:
+				continue
+			}
+			rsk, err := terraform.ParseResourceStateKey(key)
+			if err != nil {
+				return nil, err
+			}
+			if rsk.Mode != config.ManagedResourceMode {
+				continue
+			}
+			creates[rsk.Type]++
+		}
+	}
+	return creates, nil
+}
+
 // makeShutdownCh creates an interrupt listener and returns a channel.
 // A message will be sent on the channel for every interrupt received.
 func makeShutdownCh() (<-chan struct{}, func()) {
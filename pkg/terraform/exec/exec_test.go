@@ -0,0 +1,49 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlannedCreates(t *testing.T) {
+	cases := []struct {
+		name          string
+		planFile      string
+		expected      map[string]int
+		expectedError bool
+	}{
+		{
+			// The plan creates a bootstrap instance and three masters in a
+			// module, replaces a Route53 record, updates an S3 bucket,
+			// destroys a security group and reads a data source.
+			name:     "plan",
+			planFile: "testdata/plan.tfplan",
+			expected: map[string]int{
+				"aws_instance":       4,
+				"aws_route53_record": 1,
+			},
+		},
+		{
+			name:          "missing plan",
+			planFile:      "testdata/missing.tfplan",
+			expectedError: true,
+		},
+		{
+			name:          "not a plan",
+			planFile:      "exec_test.go",
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			creates, err := PlannedCreates(tc.planFile)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, creates)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package terraform
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// state is the subset of the Terraform (version 3) state file format that
+// the installer reads.
+type state struct {
+	Modules []struct {
+		Path      []string `json:"path"`
+		Resources map[string]struct {
+			Primary struct {
+				Attributes map[string]string `json:"attributes"`
+			} `json:"primary"`
+		} `json:"resources"`
+	} `json:"modules"`
+}
+
+// LookupResource returns the attributes of the resource with the given
+// address (e.g. "aws_instance.bootstrap") in the module with the given path
+// (e.g. ["root", "bootstrap"]) of the state file.
+func LookupResource(stateFile string, module []string, address string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(stateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %q", stateFile)
+	}
+
+	for _, m := range s.Modules {
+		if !reflect.DeepEqual(m.Path, module) {
+			continue
+		}
+		if r, ok := m.Resources[address]; ok {
+			return r.Primary.Attributes, nil
+		}
+	}
+	return nil, errors.Errorf("resource %q not found in %q", address, stateFile)
+}
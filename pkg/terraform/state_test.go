@@ -0,0 +1,120 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testState = `{
+    "version": 3,
+    "terraform_version": "0.11.10",
+    "modules": [
+        {
+            "path": ["root"],
+            "resources": {
+                "aws_instance.bootstrap": {
+                    "type": "aws_instance",
+                    "primary": {
+                        "id": "i-root",
+                        "attributes": {"public_ip": "192.0.2.1"}
+                    }
+                }
+            }
+        },
+        {
+            "path": ["root", "bootstrap"],
+            "resources": {
+                "aws_instance.bootstrap": {
+                    "type": "aws_instance",
+                    "primary": {
+                        "id": "i-bootstrap",
+                        "attributes": {"id": "i-bootstrap", "public_ip": "192.0.2.2"}
+                    }
+                }
+            }
+        }
+    ]
+}`
+
+func TestLookupResource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestLookupResource")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, StateFileName)
+	if err := ioutil.WriteFile(stateFile, []byte(testState), 0600); err != nil {
+		t.Fatalf("failed to write state file: %v", err)
+	}
+	invalidFile := filepath.Join(dir, "invalid.tfstate")
+	if err := ioutil.WriteFile(invalidFile, []byte("{"), 0600); err != nil {
+		t.Fatalf("failed to write state file: %v", err)
+	}
+
+	cases := []struct {
+		name          string
+		stateFile     string
+		module        []string
+		address       string
+		expected      map[string]string
+		expectedError string
+	}{
+		{
+			name:      "module resource",
+			stateFile: stateFile,
+			module:    []string{"root", "bootstrap"},
+			address:   "aws_instance.bootstrap",
+			expected:  map[string]string{"id": "i-bootstrap", "public_ip": "192.0.2.2"},
+		},
+		{
+			name:      "root resource",
+			stateFile: stateFile,
+			module:    []string{"root"},
+			address:   "aws_instance.bootstrap",
+			expected:  map[string]string{"public_ip": "192.0.2.1"},
+		},
+		{
+			name:          "missing module",
+			stateFile:     stateFile,
+			module:        []string{"root", "masters"},
+			address:       "aws_instance.bootstrap",
+			expectedError: `^resource "aws_instance\.bootstrap" not found in ".*terraform\.tfstate"$`,
+		},
+		{
+			name:          "missing resource",
+			stateFile:     stateFile,
+			module:        []string{"root", "bootstrap"},
+			address:       "aws_instance.master",
+			expectedError: `^resource "aws_instance\.master" not found in ".*terraform\.tfstate"$`,
+		},
+		{
+			name:          "invalid state file",
+			stateFile:     invalidFile,
+			module:        []string{"root"},
+			address:       "aws_instance.bootstrap",
+			expectedError: `^failed to unmarshal ".*invalid\.tfstate": `,
+		},
+		{
+			name:          "missing state file",
+			stateFile:     filepath.Join(dir, "missing.tfstate"),
+			module:        []string{"root"},
+			address:       "aws_instance.bootstrap",
+			expectedError: `no such file or directory`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes, err := LookupResource(tc.stateFile, tc.module, tc.address)
+			if tc.expectedError != "" {
+				assert.Regexp(t, tc.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, attributes)
+		})
+	}
+}
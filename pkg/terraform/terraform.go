@@ -11,6 +11,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/installer/pkg/lineprinter"
+	"github.com/openshift/installer/pkg/metrics"
 	texec "github.com/openshift/installer/pkg/terraform/exec"
 	"github.com/openshift/installer/pkg/terraform/exec/plugins"
 )
@@ -21,6 +22,9 @@ const (
 
 	// VarFileName is the default name for Terraform var file.
 	VarFileName string = "terraform.tfvars"
+
+	// PlanFileName is the default name for Terraform plan files.
+	PlanFileName string = "terraform.tfplan"
 )
 
 // Apply unpacks the platform-specific Terraform modules into the
@@ -28,6 +32,8 @@ const (
 // apply'.  It returns the absolute path of the tfstate file, rooted
 // in the specified directory, along with any errors from Terraform.
 func Apply(dir string, platform string, extraArgs ...string) (path string, err error) {
+	defer metrics.Start(metrics.TerraformApply, platform)(&err)
+
 	err = unpackAndInit(dir, platform)
 	if err != nil {
 		return "", err
@@ -45,7 +51,10 @@ func Apply(dir string, platform string, extraArgs ...string) (path string, err e
 
 	tDebug := &lineprinter.Trimmer{WrappedPrint: logrus.Debug}
 	tError := &lineprinter.Trimmer{WrappedPrint: logrus.Error}
-	lpDebug := &lineprinter.LinePrinter{Print: tDebug.Print}
+	lpDebug := &lineprinter.LinePrinter{Print: func(args ...interface{}) {
+		tDebug.Print(args...)
+		emitResourceCreated(fmt.Sprint(args...))
+	}}
 	lpError := &lineprinter.LinePrinter{Print: tError.Print}
 	defer lpDebug.Close()
 	defer lpError.Close()
@@ -56,6 +65,43 @@ func Apply(dir string, platform string, extraArgs ...string) (path string, err e
 	return sf, nil
 }
 
+// Plan unpacks the platform-specific Terraform modules into the
+// given directory and then runs 'terraform init' and 'terraform
+// plan'.  It returns the number of resources that Terraform would
+// create, keyed by resource type.  Nothing is created or modified.
+func Plan(dir string, platform string, extraArgs ...string) (creates map[string]int, err error) {
+	err = unpackAndInit(dir, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	pf := filepath.Join(dir, PlanFileName)
+	defaultArgs := []string{
+		"-input=false",
+		fmt.Sprintf("-state=%s", filepath.Join(dir, StateFileName)),
+		fmt.Sprintf("-out=%s", pf),
+	}
+	args := append(defaultArgs, extraArgs...)
+	args = append(args, dir)
+
+	tDebug := &lineprinter.Trimmer{WrappedPrint: logrus.Debug}
+	tError := &lineprinter.Trimmer{WrappedPrint: logrus.Error}
+	lpDebug := &lineprinter.LinePrinter{Print: tDebug.Print}
+	lpError := &lineprinter.LinePrinter{Print: tError.Print}
+	defer lpDebug.Close()
+	defer lpError.Close()
+
+	if exitCode := texec.Plan(dir, args, lpDebug, lpError); exitCode != 0 {
+		return nil, errors.New("failed to plan using Terraform")
+	}
+
+	creates, err = texec.PlannedCreates(pf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read Terraform plan")
+	}
+	return creates, nil
+}
+
 // Destroy unpacks the platform-specific Terraform modules into the
 // given directory and then runs 'terraform init' and 'terraform
 // destroy'.
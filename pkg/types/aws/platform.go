@@ -4,8 +4,18 @@ package aws
 // use.
 type Platform struct {
 	// Region specifies the AWS region where the cluster will be created.
+	// +required
 	Region string `json:"region"`
 
+	// Subnets specifies the IDs of existing subnets of a VPC to install
+	// the cluster into, instead of creating a new VPC.  The machines are
+	// created in the private subnets, which route to a NAT, and there must
+	// be a public subnet, which routes to an internet gateway, in each of
+	// their availability zones.  The subnets must all be in the same VPC,
+	// and within the machine CIDR.
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+
 	// UserTags specifies additional tags for AWS resources created for the cluster.
 	// +optional
 	UserTags map[string]string `json:"userTags,omitempty"`
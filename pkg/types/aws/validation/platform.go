@@ -54,6 +54,16 @@ func ValidatePlatform(p *aws.Platform, fldPath *field.Path) field.ErrorList {
 	if _, ok := Regions[p.Region]; !ok {
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("region"), p.Region, validRegionValues))
 	}
+	seen := map[string]bool{}
+	for i, subnet := range p.Subnets {
+		switch {
+		case subnet == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("subnets").Index(i), "subnet ID must not be empty"))
+		case seen[subnet]:
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("subnets").Index(i), subnet))
+		}
+		seen[subnet] = true
+	}
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
 	}
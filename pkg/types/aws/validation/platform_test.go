@@ -29,6 +29,30 @@ func TestValidatePlatform(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "subnets",
+			platform: &aws.Platform{
+				Region:  "us-east-1",
+				Subnets: []string{"subnet-private", "subnet-public"},
+			},
+			valid: true,
+		},
+		{
+			name: "duplicate subnets",
+			platform: &aws.Platform{
+				Region:  "us-east-1",
+				Subnets: []string{"subnet-private", "subnet-private"},
+			},
+			valid: false,
+		},
+		{
+			name: "empty subnet",
+			platform: &aws.Platform{
+				Region:  "us-east-1",
+				Subnets: []string{""},
+			},
+			valid: false,
+		},
 		{
 			name: "valid machine pool",
 			platform: &aws.Platform{
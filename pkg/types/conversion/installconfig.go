@@ -1,55 +1,204 @@
 package conversion
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/pkg/errors"
 )
 
+// Change describes a field that was moved or dropped while upconverting an
+// install config.
+type Change struct {
+	// Field is the path of the field in the original install config, e.g.
+	// "networking.serviceCIDR".
+	Field string
+
+	// MovedTo is the path of the field the value was moved to, or empty if
+	// the value was dropped.
+	MovedTo string
+
+	// Reason explains why a dropped value was dropped.
+	Reason string
+
+	// Unknown is true if the value was dropped because the field is not a
+	// field of the current version, rather than because it was deprecated.
+	Unknown bool
+}
+
+// String returns a human-readable description of the change.
+func (c Change) String() string {
+	if c.MovedTo != "" {
+		return fmt.Sprintf("moved %s to %s", c.Field, c.MovedTo)
+	}
+	return fmt.Sprintf("dropped %s: %s", c.Field, c.Reason)
+}
+
+// converter upconverts an install config from one version to the next.
+type converter struct {
+	from    string
+	to      string
+	convert func(*types.InstallConfig) []Change
+}
+
+// converters is the chain of converters from the oldest supported version
+// of the install config to types.InstallConfigVersion.  Bumping the version
+// means adding a converter from the previous version here.
+var converters = []converter{
+	{from: "v1beta3", to: "v1beta4", convert: convertNetworking},
+}
+
+// deprecations relocate the deprecated fields that the current version of
+// the install config still accepts.
+var deprecations = []func(*types.InstallConfig) []Change{
+	convertNetworking,
+}
+
 // ConvertInstallConfig is modeled after the k8s conversion schemes, which is
 // how deprecated values are upconverted.
 // This updates the APIVersion to reflect the fact that we've internally
 // upconverted.
 func ConvertInstallConfig(config *types.InstallConfig) error {
-	// check that the version is convertible
-	switch config.APIVersion {
-	case types.InstallConfigVersion, "v1beta3":
-		// works
-	default:
-		return errors.Errorf("cannot upconvert from version %s", config.APIVersion)
+	_, err := Convert(config)
+	return err
+}
+
+// Convert upconverts the install config to types.InstallConfigVersion by
+// running the converters for each version in turn, and returns the fields
+// that were moved or dropped.  Deprecated fields keep their values, so that
+// consumers of the converted install config which still read them continue
+// to work.  Converting an already-converted install config changes nothing.
+func Convert(config *types.InstallConfig) ([]Change, error) {
+	var changes []Change
+	for config.APIVersion != types.InstallConfigVersion {
+		c := converterFrom(config.APIVersion)
+		if c == nil {
+			return nil, errors.Errorf("cannot upconvert from version %s", config.APIVersion)
+		}
+		changes = append(changes, c.convert(config)...)
+		config.APIVersion = c.to
 	}
-	ConvertNetworking(config)
+	for _, deprecation := range deprecations {
+		changes = append(changes, deprecation(config)...)
+	}
+	return changes, nil
+}
 
-	config.APIVersion = types.InstallConfigVersion
-	return nil
+// Migrate upconverts the install config like Convert, and also clears the
+// deprecated fields, so that the install config can be written back using
+// only the fields of the current version.
+func Migrate(config *types.InstallConfig) ([]Change, error) {
+	changes, err := Convert(config)
+	if err != nil {
+		return nil, err
+	}
+	clearDeprecatedNetworking(config)
+	return changes, nil
 }
 
-// ConvertNetworking upconverts deprecated fields in networking
+// ConvertNetworking upconverts deprecated fields in networking.
+//
+// Deprecated: Use Convert, which also reports the fields that were moved or
+// dropped.
 func ConvertNetworking(config *types.InstallConfig) {
+	convertNetworking(config)
+}
+
+func converterFrom(version string) *converter {
+	for i, c := range converters {
+		if c.from == version {
+			return &converters[i]
+		}
+	}
+	return nil
+}
+
+func moved(field, movedTo string) Change {
+	return Change{Field: field, MovedTo: movedTo}
+}
+
+func dropped(field, reason string) Change {
+	return Change{Field: field, Reason: reason}
+}
+
+// convertNetworking upconverts deprecated fields in networking.  Values of
+// deprecated fields that agree with the current fields were moved by an
+// earlier conversion and are not reported again.
+func convertNetworking(config *types.InstallConfig) []Change {
 	if config.Networking == nil {
-		return
+		return nil
 	}
 
 	netconf := config.Networking
+	var changes []Change
 
-	if len(netconf.ClusterNetwork) == 0 {
-		netconf.ClusterNetwork = netconf.DeprecatedClusterNetworks
+	clusterNetworkField := "networking.clusterNetwork"
+	if len(netconf.DeprecatedClusterNetworks) > 0 {
+		switch {
+		case len(netconf.ClusterNetwork) == 0:
+			netconf.ClusterNetwork = netconf.DeprecatedClusterNetworks
+			clusterNetworkField = "networking.clusterNetworks"
+			changes = append(changes, moved("networking.clusterNetworks", "networking.clusterNetwork"))
+		case !reflect.DeepEqual(netconf.ClusterNetwork, netconf.DeprecatedClusterNetworks):
+			changes = append(changes, dropped("networking.clusterNetworks", "networking.clusterNetwork is set"))
+		}
 	}
 
-	if len(netconf.ServiceNetwork) == 0 && netconf.DeprecatedServiceCIDR != nil {
-		netconf.ServiceNetwork = []ipnet.IPNet{*netconf.DeprecatedServiceCIDR}
+	if netconf.DeprecatedServiceCIDR != nil {
+		switch {
+		case len(netconf.ServiceNetwork) == 0:
+			netconf.ServiceNetwork = []ipnet.IPNet{*netconf.DeprecatedServiceCIDR}
+			changes = append(changes, moved("networking.serviceCIDR", "networking.serviceNetwork"))
+		case !reflect.DeepEqual(netconf.ServiceNetwork, []ipnet.IPNet{*netconf.DeprecatedServiceCIDR}):
+			changes = append(changes, dropped("networking.serviceCIDR", "networking.serviceNetwork is set"))
+		}
 	}
 
 	// Convert type to networkType if the latter is missing
-	if netconf.NetworkType == "" {
-		netconf.NetworkType = netconf.DeprecatedType
+	if netconf.DeprecatedType != "" {
+		switch netconf.NetworkType {
+		case "":
+			netconf.NetworkType = netconf.DeprecatedType
+			changes = append(changes, moved("networking.type", "networking.networkType"))
+		case netconf.DeprecatedType:
+		default:
+			changes = append(changes, dropped("networking.type", "networking.networkType is set"))
+		}
 	}
 
 	// Convert hostSubnetLength to hostPrefix
 	for i, entry := range netconf.ClusterNetwork {
-		if entry.HostPrefix == 0 && entry.DeprecatedHostSubnetLength != 0 {
-			_, size := entry.CIDR.Mask.Size()
-			netconf.ClusterNetwork[i].HostPrefix = int32(size) - entry.DeprecatedHostSubnetLength
+		if entry.DeprecatedHostSubnetLength == 0 {
+			continue
+		}
+		field := fmt.Sprintf("%s[%d].hostSubnetLength", clusterNetworkField, i)
+		_, size := entry.CIDR.Mask.Size()
+		hostPrefix := int32(size) - entry.DeprecatedHostSubnetLength
+		switch entry.HostPrefix {
+		case 0:
+			netconf.ClusterNetwork[i].HostPrefix = hostPrefix
+			changes = append(changes, moved(field, fmt.Sprintf("networking.clusterNetwork[%d].hostPrefix", i)))
+		case hostPrefix:
+		default:
+			changes = append(changes, dropped(field, "hostPrefix is set"))
 		}
 	}
+	return changes
+}
+
+// clearDeprecatedNetworking clears the deprecated fields in networking.
+func clearDeprecatedNetworking(config *types.InstallConfig) {
+	if config.Networking == nil {
+		return
+	}
+
+	netconf := config.Networking
+	netconf.DeprecatedType = ""
+	netconf.DeprecatedServiceCIDR = nil
+	netconf.DeprecatedClusterNetworks = nil
+	for i := range netconf.ClusterNetwork {
+		netconf.ClusterNetwork[i].DeprecatedHostSubnetLength = 0
+	}
 }
@@ -141,3 +141,201 @@ func TestConvertInstallConfig(t *testing.T) {
 		})
 	}
 }
+
+func oldNetworkingInstallConfig() *types.InstallConfig {
+	return &types.InstallConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1beta3",
+		},
+		Networking: &types.Networking{
+			MachineCIDR:           ipnet.MustParseCIDR("10.0.0.0/16"),
+			DeprecatedType:        "OpenShiftSDN",
+			DeprecatedServiceCIDR: ipnet.MustParseCIDR("172.30.0.0/16"),
+			DeprecatedClusterNetworks: []types.ClusterNetworkEntry{
+				{
+					CIDR:                       *ipnet.MustParseCIDR("10.128.0.0/14"),
+					DeprecatedHostSubnetLength: 9,
+				},
+			},
+		},
+	}
+}
+
+func TestConvert(t *testing.T) {
+	cases := []struct {
+		name            string
+		config          *types.InstallConfig
+		expectedChanges []string
+		expectedError   string
+	}{
+		{
+			name:   "current version",
+			config: &types.InstallConfig{TypeMeta: metav1.TypeMeta{APIVersion: types.InstallConfigVersion}},
+		},
+		{
+			name:   "old networking",
+			config: oldNetworkingInstallConfig(),
+			expectedChanges: []string{
+				"moved networking.clusterNetworks to networking.clusterNetwork",
+				"moved networking.serviceCIDR to networking.serviceNetwork",
+				"moved networking.type to networking.networkType",
+				"moved networking.clusterNetworks[0].hostSubnetLength to networking.clusterNetwork[0].hostPrefix",
+			},
+		},
+		{
+			name: "conflicting networking",
+			config: func() *types.InstallConfig {
+				c := oldNetworkingInstallConfig()
+				c.APIVersion = types.InstallConfigVersion
+				c.Networking.NetworkType = "OVNKubernetes"
+				c.Networking.ServiceNetwork = []ipnet.IPNet{*ipnet.MustParseCIDR("172.31.0.0/16")}
+				c.Networking.ClusterNetwork = []types.ClusterNetworkEntry{
+					{
+						CIDR:                       *ipnet.MustParseCIDR("10.128.0.0/14"),
+						HostPrefix:                 24,
+						DeprecatedHostSubnetLength: 9,
+					},
+				}
+				return c
+			}(),
+			expectedChanges: []string{
+				"dropped networking.clusterNetworks: networking.clusterNetwork is set",
+				"dropped networking.serviceCIDR: networking.serviceNetwork is set",
+				"dropped networking.type: networking.networkType is set",
+				"dropped networking.clusterNetwork[0].hostSubnetLength: hostPrefix is set",
+			},
+		},
+		{
+			name:          "unknown version",
+			config:        &types.InstallConfig{TypeMeta: metav1.TypeMeta{APIVersion: "v1alpha1"}},
+			expectedError: "cannot upconvert from version v1alpha1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changes, err := Convert(tc.config)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			var actual []string
+			for _, change := range changes {
+				actual = append(actual, change.String())
+			}
+			assert.Equal(t, tc.expectedChanges, actual)
+			assert.Equal(t, types.InstallConfigVersion, tc.config.APIVersion)
+
+			// Converting again only reports the values that are still dropped.
+			var stillDropped []Change
+			for _, change := range changes {
+				if change.MovedTo == "" {
+					stillDropped = append(stillDropped, change)
+				}
+			}
+			changes, err = Convert(tc.config)
+			assert.NoError(t, err)
+			assert.Equal(t, stillDropped, changes)
+		})
+	}
+}
+
+func TestConvertNetworking(t *testing.T) {
+	config := oldNetworkingInstallConfig()
+	ConvertNetworking(config)
+	assert.Equal(t, "OpenShiftSDN", config.Networking.NetworkType)
+	assert.Equal(t, []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16")}, config.Networking.ServiceNetwork)
+	if assert.Len(t, config.Networking.ClusterNetwork, 1) {
+		assert.Equal(t, int32(23), config.Networking.ClusterNetwork[0].HostPrefix)
+	}
+	assert.Equal(t, "v1beta3", config.APIVersion, "only the networking is converted")
+}
+
+func TestMigrate(t *testing.T) {
+	config := oldNetworkingInstallConfig()
+	changes, err := Migrate(config)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, changes, 4)
+	assert.Equal(t, &types.InstallConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: types.InstallConfigVersion,
+		},
+		Networking: &types.Networking{
+			MachineCIDR:    ipnet.MustParseCIDR("10.0.0.0/16"),
+			NetworkType:    "OpenShiftSDN",
+			ServiceNetwork: []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16")},
+			ClusterNetwork: []types.ClusterNetworkEntry{
+				{
+					CIDR:       *ipnet.MustParseCIDR("10.128.0.0/14"),
+					HostPrefix: 23,
+				},
+			},
+		},
+	}, config)
+}
+
+func TestConverters(t *testing.T) {
+	for _, c := range converters {
+		t.Run(c.from, func(t *testing.T) {
+			version := c.from
+			for i := 0; version != types.InstallConfigVersion; i++ {
+				next := converterFrom(version)
+				if next == nil || i > len(converters) {
+					t.Fatalf("no chain of converters from %s to %s", c.from, types.InstallConfigVersion)
+				}
+				version = next.to
+			}
+		})
+	}
+}
+
+func TestMigrateYAML(t *testing.T) {
+	data := []byte(`apiVersion: v1beta3
+metadata:
+  name: test-cluster
+networking:
+  clusterNetworks:
+  - cidr: 10.128.0.0/14
+    hostPrefix: 23
+    hostSubnetLength: 0
+  - cidr: 10.132.0.0/14
+    hostPrefix: 0
+    hostSubnetLength: 9
+  machineCidr: 10.0.0.0/16
+  serviceCIDR: 172.30.0.0/16
+  legacyOption: true
+  emptyOption: ""
+platform:
+  aws:
+    region: us-east-1
+    userTags: {}
+`)
+	config, changes, err := MigrateYAML(data)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var actual []string
+	for _, change := range changes {
+		actual = append(actual, change.String())
+	}
+	assert.Equal(t, []string{
+		"dropped networking.emptyOption: not a field of install-config version " + types.InstallConfigVersion,
+		"dropped networking.legacyOption: not a field of install-config version " + types.InstallConfigVersion,
+		"moved networking.clusterNetworks to networking.clusterNetwork",
+		"moved networking.serviceCIDR to networking.serviceNetwork",
+		"moved networking.clusterNetworks[1].hostSubnetLength to networking.clusterNetwork[1].hostPrefix",
+	}, actual)
+	if assert.Len(t, changes, 5) {
+		assert.True(t, changes[0].Unknown, "unknown field")
+		assert.True(t, changes[1].Unknown, "unknown field")
+		assert.False(t, changes[2].Unknown, "deprecated field")
+	}
+	assert.Equal(t, types.InstallConfigVersion, config.APIVersion)
+	assert.Equal(t, []ipnet.IPNet{*ipnet.MustParseCIDR("172.30.0.0/16")}, config.Networking.ServiceNetwork)
+	assert.Nil(t, config.Networking.DeprecatedServiceCIDR)
+}
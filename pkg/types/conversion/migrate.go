@@ -0,0 +1,134 @@
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// MigrateYAML parses an install config of any supported version and
+// migrates it like Migrate.  Fields that the current version does not know
+// about are dropped by the parsing, so they are reported as dropped too,
+// with Unknown set.
+func MigrateYAML(data []byte) (*types.InstallConfig, []Change, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal")
+	}
+	config := &types.InstallConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal")
+	}
+	if config.APIVersion == "" {
+		return nil, nil, errors.New("install-config version required")
+	}
+
+	unknown := unknownFields("", raw, reflect.TypeOf(config), fmt.Sprintf("not a field of install-config version %s", types.InstallConfigVersion))
+	for i := range unknown {
+		unknown[i].Unknown = true
+	}
+
+	changes, err := Migrate(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return config, append(unknown, changes...), nil
+}
+
+// unknownFields returns the fields that are present in raw, the decoded
+// value of a field of type t, but that t does not have.  Like the decoding,
+// field names are matched regardless of case.
+func unknownFields(path string, raw interface{}, t reflect.Type, reason string) []Change {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+
+	var changes []Change
+	switch r := raw.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(r))
+		for key := range r {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var fields map[string]reflect.Type
+		switch t.Kind() {
+		case reflect.Struct:
+			fields = jsonFields(t)
+		case reflect.Map:
+		default:
+			return nil
+		}
+		for _, key := range keys {
+			field := key
+			if path != "" {
+				field = path + "." + key
+			}
+			fieldType := t
+			if t.Kind() == reflect.Map {
+				fieldType = t.Elem()
+			} else if ft, ok := fields[strings.ToLower(key)]; ok {
+				fieldType = ft
+			} else {
+				changes = append(changes, dropped(field, reason))
+				continue
+			}
+			changes = append(changes, unknownFields(field, r[key], fieldType, reason)...)
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return nil
+		}
+		for i, value := range r {
+			changes = append(changes, unknownFields(fmt.Sprintf("%s[%d]", path, i), value, t.Elem(), reason)...)
+		}
+	}
+	return changes
+}
+
+// jsonFields returns the types of the fields of the struct type t, keyed by
+// their lower-case JSON names, with the fields of embedded structs without
+// names inlined.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		embedded := field.Type
+		for embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+		if name == "" && field.Anonymous && embedded.Kind() == reflect.Struct {
+			for embeddedName, embeddedType := range jsonFields(embedded) {
+				fields[embeddedName] = embeddedType
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
@@ -15,6 +15,11 @@ var (
 	defaultClusterNetwork = ipnet.MustParseCIDR("10.128.0.0/14")
 	defaultHostPrefix     = 23
 	defaultNetworkType    = "OpenShiftSDN"
+
+	// The defaults for clusters with an IPv6 machine CIDR.
+	defaultIPv6ServiceNetwork = ipnet.MustParseCIDR("fd02::/112")
+	defaultIPv6ClusterNetwork = ipnet.MustParseCIDR("fd01::/48")
+	defaultIPv6HostPrefix     = 64
 )
 
 // SetInstallConfigDefaults sets the defaults for the install config.
@@ -31,14 +36,18 @@ func SetInstallConfigDefaults(c *types.InstallConfig) {
 	if c.Networking.NetworkType == "" {
 		c.Networking.NetworkType = defaultNetworkType
 	}
+	serviceNetwork, clusterNetwork, hostPrefix := defaultServiceNetwork, defaultClusterNetwork, defaultHostPrefix
+	if c.Networking.MachineCIDR.IP.To4() == nil {
+		serviceNetwork, clusterNetwork, hostPrefix = defaultIPv6ServiceNetwork, defaultIPv6ClusterNetwork, defaultIPv6HostPrefix
+	}
 	if len(c.Networking.ServiceNetwork) == 0 {
-		c.Networking.ServiceNetwork = []ipnet.IPNet{*defaultServiceNetwork}
+		c.Networking.ServiceNetwork = []ipnet.IPNet{*serviceNetwork}
 	}
 	if len(c.Networking.ClusterNetwork) == 0 {
 		c.Networking.ClusterNetwork = []types.ClusterNetworkEntry{
 			{
-				CIDR:       *defaultClusterNetwork,
-				HostPrefix: int32(defaultHostPrefix),
+				CIDR:       *clusterNetwork,
+				HostPrefix: int32(hostPrefix),
 			},
 		}
 	}
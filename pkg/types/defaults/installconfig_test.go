@@ -133,6 +133,26 @@ func TestSetInstallConfigDefaults(t *testing.T) {
 				return c
 			}(),
 		},
+		{
+			name: "IPv6 machine CIDR present",
+			config: &types.InstallConfig{
+				Networking: &types.Networking{
+					MachineCIDR: ipnet.MustParseCIDR("fd00::/48"),
+				},
+			},
+			expected: func() *types.InstallConfig {
+				c := defaultInstallConfig()
+				c.Networking.MachineCIDR = ipnet.MustParseCIDR("fd00::/48")
+				c.Networking.ServiceNetwork = []ipnet.IPNet{*defaultIPv6ServiceNetwork}
+				c.Networking.ClusterNetwork = []types.ClusterNetworkEntry{
+					{
+						CIDR:       *defaultIPv6ClusterNetwork,
+						HostPrefix: int32(defaultIPv6HostPrefix),
+					},
+				}
+				return c
+			}(),
+		},
 		{
 			name: "Service network present",
 			config: &types.InstallConfig{
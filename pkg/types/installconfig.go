@@ -46,6 +46,7 @@ type InstallConfig struct {
 	SSHKey string `json:"sshKey,omitempty"`
 
 	// BaseDomain is the base domain to which the cluster should belong.
+	// +required
 	BaseDomain string `json:"baseDomain"`
 
 	// Networking defines the pod network provider in the cluster.
@@ -65,7 +66,23 @@ type InstallConfig struct {
 	Platform `json:"platform"`
 
 	// PullSecret is the secret to use when pulling images.
+	// +required
 	PullSecret string `json:"pullSecret"`
+
+	// Proxy defines the proxy settings for the cluster.
+	// If unset, the cluster will not be configured to use a proxy.
+	// +optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+
+	// AdditionalTrustBundle is a PEM-encoded X.509 certificate bundle
+	// that will be added to the nodes' trusted certificate store, e.g. to
+	// trust the certificates of a proxy or registry signed by a private CA.
+	// +optional
+	AdditionalTrustBundle string `json:"additionalTrustBundle,omitempty"`
+
+	// ImageContentSources lists sources/repositories for the release-image content.
+	// +optional
+	ImageContentSources []ImageContentSource `json:"imageContentSources,omitempty"`
 }
 
 // ClusterDomain returns the DNS domain that all records for a cluster must belong to.
@@ -73,6 +90,39 @@ func (c *InstallConfig) ClusterDomain() string {
 	return fmt.Sprintf("%s.%s", c.ObjectMeta.Name, c.BaseDomain)
 }
 
+// Proxy defines the proxy settings for the cluster.
+// At least one of HTTPProxy or HTTPSProxy is required.
+type Proxy struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of domains and CIDRs for which the
+	// proxy should not be used.  It should include the machine, service and
+	// cluster networks.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// ImageContentSource defines a list of sources/repositories that can be used
+// to pull content.  Images pulled by digest from the source are pulled from
+// the mirrors instead, in order, falling back to the source.
+type ImageContentSource struct {
+	// Source is the repository that users refer to, e.g. in image pull
+	// specifications.
+	// +required
+	Source string `json:"source"`
+
+	// Mirrors is one or more repositories that may also contain the same
+	// images.
+	// +required
+	Mirrors []string `json:"mirrors"`
+}
+
 // Platform is the configuration for the specific platform upon which to perform
 // the installation. Only one of the platform configuration should be set.
 type Platform struct {
@@ -117,7 +167,8 @@ func (p *Platform) Name() string {
 
 // Networking defines the pod network provider in the cluster.
 type Networking struct {
-	// MachineCIDR is the IP address space from which to assign machine IPs.
+	// MachineCIDR is the IPv4 or IPv6 address space from which to assign
+	// machine IPs.  AWS requires an IPv4 machine CIDR.
 	// +optional
 	// Default is 10.0.0.0/16 for all platforms other than Libvirt.
 	// For Libvirt, the default is 192.168.126.0/24.
@@ -128,15 +179,21 @@ type Networking struct {
 	// Default is OpenShiftSDN.
 	NetworkType string `json:"networkType,omitempty"`
 
-	// ClusterNetwork is the IP address pool to use for pod IPs.
+	// ClusterNetwork is the IP address pool to use for pod IPs.  There
+	// must be a cluster network for each IP family of the service networks,
+	// and the first one must have the same family as the first service
+	// network.
 	// +optional
-	// Default is 10.128.0.0/14 and a host prefix of /23
+	// Default is 10.128.0.0/14 and a host prefix of /23, or fd01::/48 and
+	// a host prefix of /64 for an IPv6 machine CIDR.
 	ClusterNetwork []ClusterNetworkEntry `json:"clusterNetwork,omitempty"`
 
-	// ServiceNetwork is the IP address pool to use for service IPs.
+	// ServiceNetwork is the IP address pools to use for service IPs.  The
+	// family of the first one is the primary IP family of the cluster, and
+	// a dual-stack cluster has both IPv4 and IPv6 networks.  The machine,
+	// service and cluster networks must not overlap.
 	// +optional
-	// Default is 172.30.0.0/16
-	// NOTE: currently only one entry is supported.
+	// Default is 172.30.0.0/16, or fd02::/112 for an IPv6 machine CIDR.
 	ServiceNetwork []ipnet.IPNet `json:"serviceNetwork,omitempty"`
 
 	// Deprected types, scheduled to be removed
@@ -158,10 +215,13 @@ type Networking struct {
 // are allocated with size 2^HostSubnetLength.
 type ClusterNetworkEntry struct {
 	// The IP block address pool
+	// +required
 	CIDR ipnet.IPNet `json:"cidr"`
 
 	// HostPrefix is the prefix size to allocate to each node from the CIDR.
-	// For example, 24 would allocate 2^8=256 adresses to each node.
+	// For example, 24 would allocate 2^8=256 adresses to each node.  It
+	// may only be omitted when the deprecated HostSubnetLength is set.
+	// +optional
 	HostPrefix int32 `json:"hostPrefix"`
 
 	// The size of blocks to allocate from the larger pool.
@@ -1,6 +1,8 @@
 package types
 
 import (
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/openshift/installer/pkg/types/openstack"
@@ -10,16 +12,44 @@ import (
 type MachinePool struct {
 	// Name is the name of the machine pool.
 	// For the control plane machine pool, the name will always be "master".
-	// For the compute machine pools, the only valid name is "worker".
+	// For the compute machine pools, the name must be unique and a valid
+	// DNS label other than "master", e.g. "worker" or "infra".
 	Name string `json:"name"`
 
 	// Replicas is the count of machines for this machine pool.
 	Replicas *int64 `json:"replicas,omitempty"`
 
+	// Autoscaling lets the cluster autoscaler scale the machine pool
+	// between the given bounds, starting from Replicas.  It is only
+	// supported for compute machine pools.
+	// +optional
+	Autoscaling *MachinePoolAutoscaling `json:"autoscaling,omitempty"`
+
+	// Labels are added to the nodes of the machine pool.  They are only
+	// supported for compute machine pools.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Taints are added to the nodes of the machine pool.  They are only
+	// supported for compute machine pools.
+	// +optional
+	Taints []corev1.Taint `json:"taints,omitempty"`
+
 	// Platform is configuration for machine pool specific to the platfrom.
 	Platform MachinePoolPlatform `json:"platform"`
 }
 
+// MachinePoolAutoscaling is the range the cluster autoscaler may scale a
+// machine pool within.  The bounds are split across the machine sets of the
+// pool in the same way as the replicas, e.g. across availability zones.
+type MachinePoolAutoscaling struct {
+	// MinReplicas is the smallest number of machines in the pool.
+	MinReplicas int64 `json:"minReplicas"`
+
+	// MaxReplicas is the largest number of machines in the pool.
+	MaxReplicas int64 `json:"maxReplicas"`
+}
+
 // MachinePoolPlatform is the platform-specific configuration for a machine
 // pool. Only one of the platforms should be set.
 type MachinePoolPlatform struct {
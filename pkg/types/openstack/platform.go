@@ -4,6 +4,7 @@ package openstack
 // machinesets use.
 type Platform struct {
 	// Region specifies the OpenStack region where the cluster will be created.
+	// +required
 	Region string `json:"region"`
 
 	// DefaultMachinePlatform is the default configuration used when
@@ -14,14 +15,17 @@ type Platform struct {
 
 	// Cloud
 	// Name of OpenStack cloud to use from clouds.yaml
+	// +required
 	Cloud string `json:"cloud"`
 
 	// ExternalNetwork
 	// The OpenStack external network name to be used for installation.
+	// +required
 	ExternalNetwork string `json:"externalNetwork"`
 
 	// FlavorName
 	// The OpenStack compute flavor to use for servers.
+	// +required
 	FlavorName string `json:"computeFlavor"`
 
 	// LbFloatingIP
@@ -8,8 +8,24 @@ import (
 	"github.com/openshift/installer/pkg/types/openstack"
 )
 
-// ValidatePlatform checks that the specified platform is valid.
+// ValidatePlatform checks that the specified platform is valid.  The cloud,
+// region, external network and flavor are only checked against the values
+// of the cloud if there is a fetcher, so that a nil fetcher can be used to
+// validate the platform offline.
 func ValidatePlatform(p *openstack.Platform, fldPath *field.Path, fetcher ValidValuesFetcher) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if fetcher != nil {
+		allErrs = append(allErrs, validateCloud(p, fldPath, fetcher)...)
+	}
+	if p.DefaultMachinePlatform != nil {
+		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
+	}
+	return allErrs
+}
+
+// validateCloud checks the platform against the values the fetcher retrieves
+// from the cloud.
+func validateCloud(p *openstack.Platform, fldPath *field.Path, fetcher ValidValuesFetcher) field.ErrorList {
 	allErrs := field.ErrorList{}
 	validClouds, err := fetcher.GetCloudNames()
 	if err != nil {
@@ -46,9 +62,6 @@ func ValidatePlatform(p *openstack.Platform, fldPath *field.Path, fetcher ValidV
 			}
 		}
 	}
-	if p.DefaultMachinePlatform != nil {
-		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
-	}
 	return allErrs
 }
 
@@ -163,3 +163,9 @@ func TestValidatePlatform(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePlatformWithoutFetcher(t *testing.T) {
+	p := validPlatform()
+	p.Cloud = "unknown-cloud"
+	assert.NoError(t, ValidatePlatform(p, field.NewPath("test-path"), nil).ToAggregate())
+}
@@ -2,12 +2,15 @@ package validation
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"sort"
 	"strings"
 
-	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"github.com/openshift/installer/pkg/redact"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
 	awsvalidation "github.com/openshift/installer/pkg/types/aws/validation"
@@ -61,6 +64,12 @@ func ValidateInstallConfig(c *types.InstallConfig, openStackValidValuesFetcher o
 	}
 	if c.Networking != nil {
 		allErrs = append(allErrs, validateNetworking(c.Networking, field.NewPath("networking"))...)
+		if c.Platform.AWS != nil && len(c.Platform.AWS.Subnets) == 0 {
+			allErrs = append(allErrs, validateAWSNetworking(c.Networking, field.NewPath("networking"))...)
+		}
+		if c.Platform.OpenStack != nil {
+			allErrs = append(allErrs, validateOpenStackNetworking(c.Networking, field.NewPath("networking"))...)
+		}
 	} else {
 		allErrs = append(allErrs, field.Required(field.NewPath("networking"), "networking is required"))
 	}
@@ -74,6 +83,15 @@ func ValidateInstallConfig(c *types.InstallConfig, openStackValidValuesFetcher o
 	if err := validate.ImagePullSecret(c.PullSecret); err != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("pullSecret"), c.PullSecret, err.Error()))
 	}
+	if c.Proxy != nil {
+		allErrs = append(allErrs, validateProxy(c.Proxy, field.NewPath("proxy"))...)
+	}
+	if c.AdditionalTrustBundle != "" {
+		if err := validate.CABundle(c.AdditionalTrustBundle); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("additionalTrustBundle"), c.AdditionalTrustBundle, err.Error()))
+		}
+	}
+	allErrs = append(allErrs, validateImageContentSources(c.ImageContentSources, field.NewPath("imageContentSources"))...)
 	return allErrs
 }
 
@@ -95,61 +113,150 @@ func validateNetworking(n *types.Networking, fldPath *field.Path) field.ErrorLis
 		if err := validate.SubnetCIDR(&sn.IPNet); err != nil {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), err.Error()))
 		}
-		if n.MachineCIDR != nil && validate.DoCIDRsOverlap(&sn.IPNet, &n.MachineCIDR.IPNet) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), "service network must not overlap with machineCIDR"))
-		}
-		for j, snn := range n.ServiceNetwork[0:i] {
-			if validate.DoCIDRsOverlap(&sn.IPNet, &snn.IPNet) {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), fmt.Sprintf("service network must not overlap with service network %d", j)))
-			}
-		}
 	}
 	if len(n.ServiceNetwork) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("serviceNetwork"), "a service network is required"))
 	}
-	// Until kubernetes supports multiple service networks e.g. dual stack
-	if len(n.ServiceNetwork) > 1 {
-		// the default stringification of this type is unreadable
-		diag := []string{}
-		for _, sn := range n.ServiceNetwork {
-			diag = append(diag, sn.String())
-		}
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork"), strings.Join(diag, ", "), "only one service network can be specified"))
-	}
 
 	for i, cn := range n.ClusterNetwork {
-		allErrs = append(allErrs, validateClusterNetwork(n, &cn, i, fldPath.Child("clusterNetwork").Index(i))...)
+		allErrs = append(allErrs, validateClusterNetwork(&cn, fldPath.Child("clusterNetwork").Index(i))...)
 	}
 	if len(n.ClusterNetwork) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("clusterNetwork"), "cluster network required"))
 	}
+
+	allErrs = append(allErrs, validateNetworksDoNotOverlap(n, fldPath)...)
+	allErrs = append(allErrs, validateIPFamilies(n, fldPath)...)
 	return allErrs
 }
 
-func validateClusterNetwork(n *types.Networking, cn *types.ClusterNetworkEntry, idx int, fldPath *field.Path) field.ErrorList {
+// network is a machine, service or cluster network, for reporting overlaps.
+type network struct {
+	name    string
+	cidr    *net.IPNet
+	fldPath *field.Path
+}
+
+// validateNetworksDoNotOverlap checks every pair of machine, service and
+// cluster networks for overlaps.  Each overlap is reported once, on the
+// later of the two networks.
+func validateNetworksDoNotOverlap(n *types.Networking, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	if err := validate.SubnetCIDR(&cn.CIDR.IPNet); err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.IPNet.String(), err.Error()))
+	var networks []network
+	if n.MachineCIDR != nil {
+		networks = append(networks, network{name: "machine CIDR", cidr: &n.MachineCIDR.IPNet, fldPath: fldPath.Child("machineCIDR")})
+	}
+	for i := range n.ServiceNetwork {
+		networks = append(networks, network{name: "service network", cidr: &n.ServiceNetwork[i].IPNet, fldPath: fldPath.Child("serviceNetwork").Index(i)})
+	}
+	for i := range n.ClusterNetwork {
+		networks = append(networks, network{name: "cluster network", cidr: &n.ClusterNetwork[i].CIDR.IPNet, fldPath: fldPath.Child("clusterNetwork").Index(i).Child("cidr")})
+	}
+	for i, b := range networks {
+		for _, a := range networks[:i] {
+			if validate.DoCIDRsOverlap(a.cidr, b.cidr) {
+				allErrs = append(allErrs, field.Invalid(b.fldPath, b.cidr.String(), fmt.Sprintf("%s must not overlap with %s %s", b.name, a.name, a.cidr)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// ipFamily returns the IP family of the network, "IPv4" or "IPv6".
+func ipFamily(n *net.IPNet) string {
+	if n.IP.To4() != nil {
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+// validateIPFamilies checks that the machine, cluster and service networks
+// agree on the IP families of the cluster.  Every family needs both a
+// service and a cluster network, the first service and cluster networks
+// set the primary family, and machines are addressed from one of the
+// families.
+func validateIPFamilies(n *types.Networking, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(n.ServiceNetwork) == 0 || len(n.ClusterNetwork) == 0 {
+		return allErrs
+	}
+
+	serviceFamilies := map[string]bool{}
+	for _, sn := range n.ServiceNetwork {
+		serviceFamilies[ipFamily(&sn.IPNet)] = true
 	}
-	if n.MachineCIDR != nil && validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &n.MachineCIDR.IPNet) {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), "cluster network must not overlap with machine CIDR"))
+	clusterFamilies := map[string]bool{}
+	for i, cn := range n.ClusterNetwork {
+		family := ipFamily(&cn.CIDR.IPNet)
+		clusterFamilies[family] = true
+		if !serviceFamilies[family] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterNetwork").Index(i).Child("cidr"), cn.CIDR.String(), fmt.Sprintf("there is no %s service network", family)))
+		}
 	}
 	for i, sn := range n.ServiceNetwork {
-		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &sn.IPNet) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), fmt.Sprintf("cluster network must not overlap with service network %d", i)))
+		if family := ipFamily(&sn.IPNet); !clusterFamilies[family] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceNetwork").Index(i), sn.String(), fmt.Sprintf("there is no %s cluster network", family)))
 		}
 	}
-	for i, acn := range n.ClusterNetwork[0:idx] {
-		if validate.DoCIDRsOverlap(&cn.CIDR.IPNet, &acn.CIDR.IPNet) {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.String(), fmt.Sprintf("cluster network must not overlap with cluster network %d", i)))
+
+	primary := ipFamily(&n.ServiceNetwork[0].IPNet)
+	if family := ipFamily(&n.ClusterNetwork[0].CIDR.IPNet); family != primary {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterNetwork").Index(0).Child("cidr"), n.ClusterNetwork[0].CIDR.String(), fmt.Sprintf("the first cluster network must be %s like the first service network", primary)))
+	}
+
+	if n.MachineCIDR != nil {
+		if family := ipFamily(&n.MachineCIDR.IPNet); !serviceFamilies[family] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("machineCIDR"), n.MachineCIDR.String(), fmt.Sprintf("there are no %s service and cluster networks", family)))
 		}
 	}
+	return allErrs
+}
+
+func validateClusterNetwork(cn *types.ClusterNetworkEntry, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if err := validate.SubnetCIDR(&cn.CIDR.IPNet); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cidr"), cn.CIDR.IPNet.String(), err.Error()))
+	}
 	if cn.HostPrefix < 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "hostPrefix must be positive"))
 	}
-	if ones, _ := cn.CIDR.Mask.Size(); cn.HostPrefix < int32(ones) {
+	ones, bits := cn.CIDR.Mask.Size()
+	if cn.HostPrefix < int32(ones) {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "cluster network host subnetwork prefix must not be larger size than CIDR "+cn.CIDR.String()))
 	}
+	if cn.HostPrefix > int32(bits) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, fmt.Sprintf("hostPrefix must not be larger than %d for an %s cluster network", bits, ipFamily(&cn.CIDR.IPNet))))
+	}
+	return allErrs
+}
+
+// validateAWSNetworking checks the machine CIDR against the requirements of
+// AWS, which creates the VPC of the cluster from it.  The overlap checks of
+// validateNetworking therefore cover the VPC CIDR too.  Clusters installed
+// into existing subnets do not create a VPC, so they are not checked.
+func validateAWSNetworking(n *types.Networking, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if n.MachineCIDR == nil {
+		return allErrs
+	}
+	if ipFamily(&n.MachineCIDR.IPNet) != "IPv4" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("machineCIDR"), n.MachineCIDR.String(), "AWS VPCs require an IPv4 machine CIDR"))
+	} else if ones, _ := n.MachineCIDR.Mask.Size(); ones < 16 || ones > 24 {
+		// AWS VPCs must be between /16 and /28, and the installer splits
+		// the VPC into subnets four bits longer, which must be /28 at most.
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("machineCIDR"), n.MachineCIDR.String(), "AWS VPC CIDRs must be between /16 and /24"))
+	}
+	return allErrs
+}
+
+// validateOpenStackNetworking checks the machine CIDR against the
+// requirements of OpenStack, where the installer creates an IPv4 network
+// and subnets from it.
+func validateOpenStackNetworking(n *types.Networking, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if n.MachineCIDR != nil && ipFamily(&n.MachineCIDR.IPNet) != "IPv4" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("machineCIDR"), n.MachineCIDR.String(), "OpenStack networks require an IPv4 machine CIDR"))
+	}
 	return allErrs
 }
 
@@ -161,6 +268,15 @@ func validateControlPlane(pool *types.MachinePool, fldPath *field.Path, platform
 	if pool.Replicas != nil && *pool.Replicas == 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), pool.Replicas, "number of control plane replicas must be positive"))
 	}
+	if len(pool.Labels) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("labels"), "labels are not supported for the control plane"))
+	}
+	if len(pool.Taints) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("taints"), "taints are not supported for the control plane"))
+	}
+	if pool.Autoscaling != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("autoscaling"), "autoscaling is not supported for the control plane"))
+	}
 	allErrs = append(allErrs, ValidateMachinePool(pool, fldPath, platform)...)
 	return allErrs
 }
@@ -168,23 +284,120 @@ func validateControlPlane(pool *types.MachinePool, fldPath *field.Path, platform
 func validateCompute(pools []types.MachinePool, fldPath *field.Path, platform string) field.ErrorList {
 	allErrs := field.ErrorList{}
 	poolNames := map[string]bool{}
-	foundPositiveReplicas := false
 	for i, p := range pools {
 		poolFldPath := fldPath.Index(i)
-		if p.Name != "worker" {
-			allErrs = append(allErrs, field.NotSupported(poolFldPath.Child("name"), p.Name, []string{"worker"}))
+		if p.Name == masterPoolName {
+			allErrs = append(allErrs, field.Invalid(poolFldPath.Child("name"), p.Name, "the master pool name is reserved for the control plane"))
+		} else if errs := validation.IsDNS1123Label(p.Name); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(poolFldPath.Child("name"), p.Name, strings.Join(errs, "; ")))
 		}
 		if poolNames[p.Name] {
 			allErrs = append(allErrs, field.Duplicate(poolFldPath.Child("name"), p.Name))
 		}
 		poolNames[p.Name] = true
-		if p.Replicas != nil && *p.Replicas > 0 {
-			foundPositiveReplicas = true
-		}
 		allErrs = append(allErrs, ValidateMachinePool(&p, poolFldPath, platform)...)
 	}
-	if !foundPositiveReplicas {
-		logrus.Warnf("There are no compute nodes specified. The cluster will not fully initialize without compute nodes.")
+	return allErrs
+}
+
+func validateProxy(p *types.Proxy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p.HTTPProxy == "" && p.HTTPSProxy == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "must include httpProxy or httpsProxy"))
+	}
+	if p.HTTPProxy != "" {
+		if err := validateProxyURL(p.HTTPProxy); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("httpProxy"), redact.URL(p.HTTPProxy), err.Error()))
+		}
+	}
+	if p.HTTPSProxy != "" {
+		if err := validateProxyURL(p.HTTPSProxy); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("httpsProxy"), redact.URL(p.HTTPSProxy), err.Error()))
+		}
+	}
+
+	_, _, invalid := noProxyCIDRs(p.NoProxy)
+	for _, entry := range invalid {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("noProxy"), p.NoProxy, fmt.Sprintf("invalid CIDR %q", entry)))
+	}
+	return allErrs
+}
+
+// noProxyCIDRs returns the CIDRs in the no-proxy list, whether the list
+// contains "*" to bypass the proxy for everything, and the entries that
+// look like CIDRs but are not.
+func noProxyCIDRs(noProxy string) (cidrs []*net.IPNet, all bool, invalid []string) {
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+		case entry == "*":
+			all = true
+		case strings.Contains(entry, "/"):
+			_, cidr, err := net.ParseCIDR(entry)
+			if err != nil {
+				invalid = append(invalid, entry)
+				continue
+			}
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs, all, invalid
+}
+
+// validateProxyURL checks that the proxy URL is an absolute http or https
+// URL with a host.
+func validateProxyURL(proxy string) error {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		// The url.Error quotes the whole URL, credentials included.
+		if uerr, ok := err.(*url.Error); ok {
+			return uerr.Err
+		}
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q, must be http or https", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("no host")
+	}
+	return nil
+}
+
+// missingNoProxyNetwork returns a description of the network if it is not
+// contained in any of the no-proxy CIDRs.
+func missingNoProxyNetwork(noProxyCIDRs []*net.IPNet, name string, network *net.IPNet) []string {
+	ones, bits := network.Mask.Size()
+	for _, cidr := range noProxyCIDRs {
+		cidrOnes, cidrBits := cidr.Mask.Size()
+		if cidrBits == bits && cidrOnes <= ones && cidr.Contains(network.IP) {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("%s %s", name, network.String())}
+}
+
+func validateImageContentSources(groups []types.ImageContentSource, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	sources := map[string]bool{}
+	for i, group := range groups {
+		groupf := fldPath.Index(i)
+		if err := validate.ImageRepository(group.Source); err != nil {
+			allErrs = append(allErrs, field.Invalid(groupf.Child("source"), group.Source, err.Error()))
+		}
+		if sources[group.Source] {
+			allErrs = append(allErrs, field.Duplicate(groupf.Child("source"), group.Source))
+		}
+		sources[group.Source] = true
+		if len(group.Mirrors) == 0 {
+			allErrs = append(allErrs, field.Required(groupf.Child("mirrors"), "at least one mirror is required"))
+		}
+		for j, mirror := range group.Mirrors {
+			if err := validate.ImageRepository(mirror); err != nil {
+				allErrs = append(allErrs, field.Invalid(groupf.Child("mirrors").Index(j), mirror, err.Error()))
+			}
+		}
 	}
 	return allErrs
 }
@@ -2,7 +2,11 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types"
@@ -24,10 +28,69 @@ func ValidateMachinePool(p *types.MachinePool, fldPath *field.Path, platform str
 	} else {
 		allErrs = append(allErrs, field.Required(fldPath.Child("replicas"), "replicas is required"))
 	}
+	if p.Autoscaling != nil {
+		allErrs = append(allErrs, validateAutoscaling(p.Autoscaling, p.Replicas, fldPath)...)
+	}
+	allErrs = append(allErrs, metavalidation.ValidateLabels(p.Labels, fldPath.Child("labels"))...)
+	allErrs = append(allErrs, validateTaints(p.Taints, fldPath.Child("taints"))...)
 	allErrs = append(allErrs, validateMachinePoolPlatform(&p.Platform, fldPath.Child("platform"), platform)...)
 	return allErrs
 }
 
+// validateAutoscaling checks the autoscaling bounds of the machine pool at
+// fldPath, and that the replicas of the pool are within them.
+func validateAutoscaling(a *types.MachinePoolAutoscaling, replicas *int64, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	autoscalingPath := fldPath.Child("autoscaling")
+	if a.MinReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(autoscalingPath.Child("minReplicas"), a.MinReplicas, "minimum number of replicas must not be negative"))
+	}
+	if a.MaxReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(autoscalingPath.Child("maxReplicas"), a.MaxReplicas, "maximum number of replicas must be positive"))
+	}
+	if a.MinReplicas > a.MaxReplicas {
+		allErrs = append(allErrs, field.Invalid(autoscalingPath.Child("minReplicas"), a.MinReplicas, fmt.Sprintf("minimum number of replicas must not be larger than the maximum (%d)", a.MaxReplicas)))
+	} else if replicas != nil && (*replicas < a.MinReplicas || *replicas > a.MaxReplicas) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *replicas, fmt.Sprintf("number of replicas must be between %d and %d when autoscaling", a.MinReplicas, a.MaxReplicas)))
+	}
+	return allErrs
+}
+
+var supportedTaintEffects = []string{
+	string(corev1.TaintEffectNoSchedule),
+	string(corev1.TaintEffectPreferNoSchedule),
+	string(corev1.TaintEffectNoExecute),
+}
+
+func validateTaints(taints []corev1.Taint, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[corev1.Taint]bool{}
+	for i, taint := range taints {
+		taintPath := fldPath.Index(i)
+		if errs := validation.IsQualifiedName(taint.Key); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(taintPath.Child("key"), taint.Key, strings.Join(errs, "; ")))
+		}
+		if taint.Value != "" {
+			if errs := validation.IsValidLabelValue(taint.Value); len(errs) > 0 {
+				allErrs = append(allErrs, field.Invalid(taintPath.Child("value"), taint.Value, strings.Join(errs, "; ")))
+			}
+		}
+		switch taint.Effect {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		case "":
+			allErrs = append(allErrs, field.Required(taintPath.Child("effect"), "taint effect is required"))
+		default:
+			allErrs = append(allErrs, field.NotSupported(taintPath.Child("effect"), taint.Effect, supportedTaintEffects))
+		}
+		key := corev1.Taint{Key: taint.Key, Effect: taint.Effect}
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(taintPath, fmt.Sprintf("%s:%s", taint.Key, taint.Effect)))
+		}
+		seen[key] = true
+	}
+	return allErrs
+}
+
 func validateMachinePoolPlatform(p *types.MachinePoolPlatform, fldPath *field.Path, platform string) field.ErrorList {
 	allErrs := field.ErrorList{}
 	validate := func(n string, value interface{}, validation func(*field.Path) field.ErrorList) {
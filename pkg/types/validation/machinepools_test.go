@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 
@@ -53,6 +54,125 @@ func TestValidateMachinePool(t *testing.T) {
 			platform: "aws",
 			valid:    false,
 		},
+		{
+			name: "valid labels and taints",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Labels = map[string]string{"example.com/gpu": "true"}
+				p.Taints = []corev1.Taint{
+					{Key: "example.com/gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+					{Key: "example.com/gpu", Value: "true", Effect: corev1.TaintEffectNoExecute},
+				}
+				return p
+			}(),
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "invalid label",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Labels = map[string]string{"bad label": "true"}
+				return p
+			}(),
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "invalid taint key",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Taints = []corev1.Taint{{Key: "bad key", Effect: corev1.TaintEffectNoSchedule}}
+				return p
+			}(),
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "missing taint effect",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Taints = []corev1.Taint{{Key: "example.com/gpu"}}
+				return p
+			}(),
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "unsupported taint effect",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Taints = []corev1.Taint{{Key: "example.com/gpu", Effect: "Sometimes"}}
+				return p
+			}(),
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "duplicate taint",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Taints = []corev1.Taint{
+					{Key: "example.com/gpu", Value: "a", Effect: corev1.TaintEffectNoSchedule},
+					{Key: "example.com/gpu", Value: "b", Effect: corev1.TaintEffectNoSchedule},
+				}
+				return p
+			}(),
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "valid autoscaling",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Autoscaling = &types.MachinePoolAutoscaling{MinReplicas: 0, MaxReplicas: 3}
+				return p
+			}(),
+			platform: "aws",
+			valid:    true,
+		},
+		{
+			name: "autoscaling minimum larger than maximum",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Autoscaling = &types.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 2}
+				return p
+			}(),
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "autoscaling without maximum",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Replicas = pointer.Int64Ptr(0)
+				p.Autoscaling = &types.MachinePoolAutoscaling{}
+				return p
+			}(),
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "replicas below autoscaling minimum",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Autoscaling = &types.MachinePoolAutoscaling{MinReplicas: 2, MaxReplicas: 4}
+				return p
+			}(),
+			platform: "aws",
+			valid:    false,
+		},
+		{
+			name: "replicas above autoscaling maximum",
+			pool: func() *types.MachinePool {
+				p := validMachinePool()
+				p.Replicas = pointer.Int64Ptr(5)
+				p.Autoscaling = &types.MachinePoolAutoscaling{MinReplicas: 2, MaxReplicas: 4}
+				return p
+			}(),
+			platform: "aws",
+			valid:    false,
+		},
 		{
 			name: "valid aws",
 			pool: func() *types.MachinePool {
@@ -0,0 +1,80 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// defaultMaxPods is the number of pods the kubelet runs on a node by
+	// default.
+	defaultMaxPods = 250
+)
+
+// WarnInstallConfig returns the problems with the specified install config
+// that do not prevent the installation, but are likely to be mistakes.  They
+// are field errors, so that they can be reported like the errors from
+// ValidateInstallConfig.
+func WarnInstallConfig(c *types.InstallConfig) field.ErrorList {
+	warnings := field.ErrorList{}
+	if c.ControlPlane != nil && c.ControlPlane.Replicas != nil {
+		if replicas := *c.ControlPlane.Replicas; replicas > 1 && replicas%2 == 0 {
+			warnings = append(warnings, field.Invalid(field.NewPath("controlPlane", "replicas"), replicas, fmt.Sprintf("etcd tolerates as many member failures with %d control plane replicas as with %d", replicas, replicas-1)))
+		}
+	}
+
+	var computeReplicas int64
+	for _, p := range c.Compute {
+		if p.Replicas != nil {
+			computeReplicas += *p.Replicas
+		}
+	}
+	if computeReplicas == 0 {
+		warnings = append(warnings, field.Invalid(field.NewPath("compute"), computeReplicas, "there are no compute replicas; the cluster will not fully initialize without compute nodes"))
+	}
+
+	if c.Networking != nil {
+		for i, cn := range c.Networking.ClusterNetwork {
+			_, bits := cn.CIDR.Mask.Size()
+			size := bits - int(cn.HostPrefix)
+			if cn.HostPrefix <= 0 || size < 0 || size >= 16 {
+				continue
+			}
+			if addresses := 1 << uint(size); addresses < defaultMaxPods {
+				warnings = append(warnings, field.Invalid(field.NewPath("networking", "clusterNetwork").Index(i).Child("hostPrefix"), cn.HostPrefix, fmt.Sprintf("a /%d host subnet has %d addresses, fewer than the %d pods each node runs by default", cn.HostPrefix, addresses, defaultMaxPods)))
+			}
+		}
+	}
+
+	if c.Proxy != nil && c.Networking != nil {
+		warnings = append(warnings, warnNoProxy(c.Proxy, c.Networking, field.NewPath("proxy", "noProxy"))...)
+	}
+	return warnings
+}
+
+// warnNoProxy warns about the cluster's own networks that the proxy is not
+// bypassed for.
+func warnNoProxy(p *types.Proxy, n *types.Networking, fldPath *field.Path) field.ErrorList {
+	cidrs, all, _ := noProxyCIDRs(p.NoProxy)
+	if all {
+		return nil
+	}
+	var networks []string
+	if n.MachineCIDR != nil {
+		networks = append(networks, missingNoProxyNetwork(cidrs, "machine", &n.MachineCIDR.IPNet)...)
+	}
+	for _, sn := range n.ServiceNetwork {
+		networks = append(networks, missingNoProxyNetwork(cidrs, "service", &sn.IPNet)...)
+	}
+	for _, cn := range n.ClusterNetwork {
+		networks = append(networks, missingNoProxyNetwork(cidrs, "cluster", &cn.CIDR.IPNet)...)
+	}
+	if len(networks) == 0 {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(fldPath, p.NoProxy, fmt.Sprintf("does not include the %s network(s); traffic within the cluster may be sent through the proxy", strings.Join(networks, ", ")))}
+}
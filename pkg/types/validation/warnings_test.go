@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/installer/pkg/ipnet"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestWarnInstallConfig(t *testing.T) {
+	cases := []struct {
+		name             string
+		installConfig    *types.InstallConfig
+		expectedWarnings []string
+	}{
+		{
+			name: "no warnings",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.ClusterNetwork[0].HostPrefix = 24
+				return c
+			}(),
+		},
+		{
+			name: "even control plane replicas",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.ClusterNetwork[0].HostPrefix = 24
+				c.ControlPlane.Replicas = pointer.Int64Ptr(4)
+				return c
+			}(),
+			expectedWarnings: []string{
+				`controlPlane.replicas: Invalid value: 4: etcd tolerates as many member failures with 4 control plane replicas as with 3`,
+			},
+		},
+		{
+			name: "no compute replicas",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.ClusterNetwork[0].HostPrefix = 24
+				c.Compute[0].Replicas = pointer.Int64Ptr(0)
+				return c
+			}(),
+			expectedWarnings: []string{
+				`compute: Invalid value: 0: there are no compute replicas; the cluster will not fully initialize without compute nodes`,
+			},
+		},
+		{
+			name: "small host subnets",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.ClusterNetwork = append(c.Networking.ClusterNetwork, types.ClusterNetworkEntry{
+					CIDR:       *ipnet.MustParseCIDR("fd01::/48"),
+					HostPrefix: 64,
+				})
+				return c
+			}(),
+			expectedWarnings: []string{
+				`networking.clusterNetwork[0].hostPrefix: Invalid value: 28: a /28 host subnet has 16 addresses, fewer than the 250 pods each node runs by default`,
+			},
+		},
+		{
+			name: "proxy not bypassed for cluster networks",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.ClusterNetwork[0].HostPrefix = 24
+				c.Proxy = &types.Proxy{
+					HTTPProxy: "http://proxy.example.com:3128",
+					NoProxy:   ".example.com,10.0.0.0/16",
+				}
+				return c
+			}(),
+			expectedWarnings: []string{
+				`proxy.noProxy: Invalid value: ".example.com,10.0.0.0/16": does not include the service 172.30.0.0/16, cluster 192.168.1.0/24 network(s); traffic within the cluster may be sent through the proxy`,
+			},
+		},
+		{
+			name: "proxy bypassed for everything",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking.ClusterNetwork[0].HostPrefix = 24
+				c.Proxy = &types.Proxy{
+					HTTPProxy: "http://proxy.example.com:3128",
+					NoProxy:   "*",
+				}
+				return c
+			}(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var warnings []string
+			for _, w := range WarnInstallConfig(tc.installConfig) {
+				warnings = append(warnings, w.Error())
+			}
+			assert.Equal(t, tc.expectedWarnings, warnings)
+		})
+	}
+}
@@ -2,11 +2,14 @@
 package validate
 
 import (
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"golang.org/x/crypto/ssh"
@@ -15,6 +18,18 @@ import (
 )
 
 var (
+	// imageRepositoryRegexp matches a repository with a registry host and
+	// no tag or digest, following the grammar of Docker references.
+	imageRepositoryRegexp = func() *regexp.Regexp {
+		domainComponent := `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])`
+		domain := domainComponent + `(?:\.` + domainComponent + `)*(?::[0-9]+)?`
+		pathComponent := `[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*`
+		return regexp.MustCompile(`^` + domain + `(?:/` + pathComponent + `)*$`)
+	}()
+
+	imageTagRegexp    = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	imageDigestRegexp = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
+
 	dockerBridgeCIDR = func() *net.IPNet {
 		_, cidr, _ := net.ParseCIDR("172.17.0.0/16")
 		return cidr
@@ -72,11 +87,8 @@ func ClusterName(v string) error {
 	return validateSubdomain(v)
 }
 
-// SubnetCIDR checks if the given IP net is a valid CIDR.
+// SubnetCIDR checks if the given IP net is a valid IPv4 or IPv6 CIDR.
 func SubnetCIDR(cidr *net.IPNet) error {
-	if cidr.IP.To4() == nil {
-		return errors.New("must use IPv4")
-	}
 	if cidr.IP.IsUnspecified() {
 		return errors.New("address must be specified")
 	}
@@ -113,3 +125,81 @@ func URI(uri string) error {
 	}
 	return nil
 }
+
+// CABundle checks that the given string is a PEM bundle of one or more
+// certificates and returns an error if not.
+func CABundle(bundle string) error {
+	rest := []byte(bundle)
+	count := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		count++
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("PEM block %d is a %s, not a certificate", count, block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("certificate %d is invalid: %v", count, err)
+		}
+	}
+	if count == 0 {
+		return errors.New("no certificates found")
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return errors.New("unexpected content after the last certificate")
+	}
+	return nil
+}
+
+// ImageRepository checks that the given string is an image repository
+// including the registry host, like quay.io/openshift/origin-release, and
+// without a tag or digest.
+func ImageRepository(repository string) error {
+	if !imageRepositoryRegexp.MatchString(repository) {
+		return fmt.Errorf("invalid repository %q, must be a registry host optionally followed by a lowercase path, without a tag or digest", repository)
+	}
+	return nil
+}
+
+// ImageReference checks that the given string is an image pull spec which
+// references the image either by tag or by digest, like
+// quay.io/openshift/origin-release:v4.0.
+func ImageReference(ref string) error {
+	return imageReference(ref, false)
+}
+
+// ImageDigestReference checks that the given string is an image pull spec
+// which references the image by digest, like
+// quay.io/openshift/origin-release@sha256:<hex>.
+func ImageDigestReference(ref string) error {
+	return imageReference(ref, true)
+}
+
+func imageReference(ref string, requireDigest bool) error {
+	if i := strings.Index(ref, "@"); i >= 0 {
+		if err := ImageRepository(ref[:i]); err != nil {
+			return err
+		}
+		if !imageDigestRegexp.MatchString(ref[i+1:]) {
+			return fmt.Errorf("invalid digest %q", ref[i+1:])
+		}
+		return nil
+	}
+	if requireDigest {
+		return fmt.Errorf("%q must reference the image by digest, like <repository>@sha256:<digest>", ref)
+	}
+	i := strings.LastIndex(ref, ":")
+	if i < 0 || strings.Contains(ref[i:], "/") {
+		return fmt.Errorf("%q must reference the image by tag or digest", ref)
+	}
+	if err := ImageRepository(ref[:i]); err != nil {
+		return err
+	}
+	if !imageTagRegexp.MatchString(ref[i+1:]) {
+		return fmt.Errorf("invalid tag %q", ref[i+1:])
+	}
+	return nil
+}
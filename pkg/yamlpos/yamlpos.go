@@ -0,0 +1,271 @@
+// Package yamlpos locates the fields of a YAML document, so that errors
+// about the fields can point at the lines of the document that set them.
+package yamlpos
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	// keyPattern matches the index or key elements of field paths.
+	keyPattern = regexp.MustCompile(`\[([^\]]*)\]`)
+
+	// indexPattern matches the index elements of field paths.
+	indexPattern = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Position is a 1-based line and column in a document.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// File is a YAML document with the positions of its fields.
+type File struct {
+	// Name is the name of the file, which prefixes the positions.
+	Name string
+
+	lines     []string
+	positions map[string]Position
+}
+
+// container is a mapping or sequence that the lines being parsed belong to.
+type container struct {
+	indent   int
+	path     string
+	sequence bool
+	next     int
+}
+
+// Parse records the position of every field of the YAML document in data.
+// Only the block style is followed: the fields within flow mappings and
+// sequences, e.g. "{name: worker}", are located at their enclosing field.
+// Parse does not fail on malformed documents, since it is only used to
+// locate errors in documents that have already been decoded.
+func Parse(name string, data []byte) *File {
+	f := &File{
+		Name:      name,
+		lines:     strings.Split(strings.Replace(string(data), "\r\n", "\n", -1), "\n"),
+		positions: map[string]Position{},
+	}
+
+	stack := []*container{{indent: -1}}
+	var pending *container
+	blockIndent, flowDepth := -1, 0
+	for i, line := range f.lines {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if blockIndent >= 0 {
+			if trimmed == "" || indent > blockIndent {
+				continue
+			}
+			blockIndent = -1
+		}
+		if flowDepth > 0 {
+			flowDepth += depth(line)
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		if trimmed == "..." {
+			break
+		}
+
+		column, rest := indent, line[indent:]
+		if pending != nil {
+			switch {
+			case isSequenceItem(rest) && column >= pending.indent:
+				stack = append(stack, &container{indent: column, path: pending.path, sequence: true})
+			case column > pending.indent:
+				stack = append(stack, &container{indent: column, path: pending.path})
+			}
+			pending = nil
+		}
+		for len(stack) > 1 {
+			top := stack[len(stack)-1]
+			if top.indent > column || top.indent == column && top.sequence && !isSequenceItem(rest) {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			break
+		}
+		if top := stack[len(stack)-1]; top.indent < 0 {
+			top.indent = column
+		}
+
+		// Sequence items may start with another sequence or with the
+		// first key of a mapping on the same line.
+		for top := stack[len(stack)-1]; top.sequence && isSequenceItem(rest); top = stack[len(stack)-1] {
+			path := fmt.Sprintf("%s[%d]", top.path, top.next)
+			top.next++
+			f.positions[path] = Position{Line: i + 1, Column: column + 1}
+
+			item := strings.TrimLeft(rest[1:], " ")
+			itemColumn := column + len(rest) - len(item)
+			switch {
+			case item == "" || strings.HasPrefix(item, "#"):
+				pending = &container{indent: column, path: path}
+			case isSequenceItem(item):
+				stack = append(stack, &container{indent: itemColumn, path: path, sequence: true})
+			case key(item) != "":
+				stack = append(stack, &container{indent: itemColumn, path: path})
+			}
+			column, rest = itemColumn, item
+		}
+		if pending != nil {
+			continue
+		}
+
+		top := stack[len(stack)-1]
+		k := key(rest)
+		if top.sequence || k == "" {
+			// A scalar item, or the continuation of a multi-line scalar.
+			continue
+		}
+		path := k
+		if top.path != "" {
+			path = top.path + "." + k
+		}
+		f.positions[path] = Position{Line: i + 1, Column: column + 1}
+
+		value := strings.TrimSpace(rest[strings.Index(rest, ":")+1:])
+		if j := strings.Index(value, " #"); j >= 0 {
+			value = strings.TrimSpace(value[:j])
+		}
+		switch {
+		case value == "" || strings.HasPrefix(value, "#"):
+			pending = &container{indent: column, path: path}
+		case strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">"):
+			blockIndent = column
+		case strings.HasPrefix(value, "{") || strings.HasPrefix(value, "["):
+			flowDepth = depth(value)
+		}
+	}
+	return f
+}
+
+// isSequenceItem returns true if the line content starts a sequence item.
+func isSequenceItem(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ")
+}
+
+// key returns the key of the line content, or an empty string if the line
+// content is not a key.
+func key(content string) string {
+	if content == "" {
+		return ""
+	}
+	if quote := content[0]; quote == '"' || quote == '\'' {
+		end := strings.IndexByte(content[1:], quote)
+		if end < 0 || !strings.HasPrefix(strings.TrimLeft(content[end+2:], " "), ":") {
+			return ""
+		}
+		return content[1 : end+1]
+	}
+	if strings.HasPrefix(content, "{") || strings.HasPrefix(content, "[") || strings.HasPrefix(content, "#") {
+		return ""
+	}
+	end := strings.Index(content, ": ")
+	if end < 0 {
+		if !strings.HasSuffix(content, ":") {
+			return ""
+		}
+		end = len(content) - 1
+	}
+	return strings.TrimRight(content[:end], " ")
+}
+
+// depth returns how many flow collections the line opens, less how many it
+// closes, ignoring brackets in quoted strings.
+func depth(line string) int {
+	d := 0
+	var quote rune
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '{' || r == '[':
+			d++
+		case r == '}' || r == ']':
+			d--
+		case r == '#' && d == 0:
+			return d
+		}
+	}
+	return d
+}
+
+// Position returns the position of the field with the given path, as
+// formatted by field.Path, or of the closest enclosing field that was
+// located.  It returns false if no enclosing field was located either.
+func (f *File) Position(path string) (Position, bool) {
+	path = keyPattern.ReplaceAllStringFunc(path, func(element string) string {
+		if key := element[1 : len(element)-1]; !indexPattern.MatchString(key) {
+			return "." + key
+		}
+		return element
+	})
+	for path != "" {
+		if position, ok := f.positions[path]; ok {
+			return position, true
+		}
+		path = path[:strings.LastIndexAny(path, ".[")+1]
+		path = strings.TrimRight(path, ".[")
+	}
+	return Position{}, false
+}
+
+// Locate returns the file name and position of the field with the given
+// path, in the "name:line:column" format of compiler errors.
+func (f *File) Locate(path string) string {
+	position, ok := f.Position(path)
+	if !ok {
+		return f.Name
+	}
+	return fmt.Sprintf("%s:%d:%d", f.Name, position.Line, position.Column)
+}
+
+// Errors returns an error listing each of the errors with its position and
+// an excerpt of the document, or nil if the list is empty.
+func (f *File) Errors(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ErrorList{File: f, Errors: errs}
+}
+
+// ErrorList is a list of errors about the fields of a YAML document.
+type ErrorList struct {
+	File   *File
+	Errors field.ErrorList
+}
+
+// Error formats the errors like a compiler does, with the position of each
+// error followed by the line of the document and a marker at the column:
+//
+//	install-config.yaml:9:5: networking.clusterNetwork[0].hostPrefix: Invalid value: 33: ...
+//	   9 |     hostPrefix: 33
+//	     |     ^
+func (e *ErrorList) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		message := fmt.Sprintf("%s: %v", e.File.Locate(err.Field), err)
+		if position, ok := e.File.Position(err.Field); ok {
+			number := fmt.Sprintf("%5d", position.Line)
+			message += fmt.Sprintf("\n%s | %s\n%s | %s^",
+				number, e.File.lines[position.Line-1],
+				strings.Repeat(" ", len(number)), strings.Repeat(" ", position.Column-1))
+		}
+		messages = append(messages, message)
+	}
+	return strings.Join(messages, "\n")
+}
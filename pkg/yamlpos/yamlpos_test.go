@@ -0,0 +1,90 @@
+package yamlpos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const document = `apiVersion: v1beta4
+baseDomain: example.com # the base domain
+metadata:
+  name: test
+  labels:
+    "team": installer
+compute:
+- name: worker
+  platform:
+    aws:
+      zones: [us-east-1a,
+        us-east-1b]
+      type: m4.large
+- name: infra
+  taints:
+    - key: role
+      effect: NoSchedule
+networking:
+  clusterNetwork:
+  -
+    cidr: 10.128.0.0/14
+    hostPrefix: 23
+  serviceNetwork:
+  - 172.30.0.0/16
+sshKey: |
+  ssh-rsa AAAA
+  name: not a field
+platform: {aws: {region: us-east-1}}
+pullSecret: '{"auths": {}}'
+`
+
+func TestPosition(t *testing.T) {
+	f := Parse("install-config.yaml", []byte(document))
+	cases := []struct {
+		path     string
+		expected Position
+		notFound bool
+	}{
+		{path: "apiVersion", expected: Position{Line: 1, Column: 1}},
+		{path: "baseDomain", expected: Position{Line: 2, Column: 1}},
+		{path: "metadata.name", expected: Position{Line: 4, Column: 3}},
+		{path: "metadata.labels[team]", expected: Position{Line: 6, Column: 5}},
+		{path: "compute[0]", expected: Position{Line: 8, Column: 1}},
+		{path: "compute[0].name", expected: Position{Line: 8, Column: 3}},
+		{path: "compute[0].platform.aws.zones[1]", expected: Position{Line: 11, Column: 7}},
+		{path: "compute[0].platform.aws.type", expected: Position{Line: 13, Column: 7}},
+		{path: "compute[1].name", expected: Position{Line: 14, Column: 3}},
+		{path: "compute[1].taints[0].effect", expected: Position{Line: 17, Column: 7}},
+		{path: "networking.clusterNetwork[0]", expected: Position{Line: 20, Column: 3}},
+		{path: "networking.clusterNetwork[0].hostPrefix", expected: Position{Line: 22, Column: 5}},
+		{path: "networking.clusterNetwork[0].cidr", expected: Position{Line: 21, Column: 5}},
+		{path: "networking.serviceNetwork[0]", expected: Position{Line: 24, Column: 3}},
+		{path: "name", notFound: true},
+		{path: "sshKey", expected: Position{Line: 25, Column: 1}},
+		{path: "platform.aws.region", expected: Position{Line: 28, Column: 1}},
+		{path: "pullSecret", expected: Position{Line: 29, Column: 1}},
+		{path: "controlPlane.replicas", notFound: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			position, ok := f.Position(tc.path)
+			assert.Equal(t, !tc.notFound, ok)
+			assert.Equal(t, tc.expected, position)
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	f := Parse("install-config.yaml", []byte(document))
+	assert.NoError(t, f.Errors(nil))
+
+	err := f.Errors(field.ErrorList{
+		field.Invalid(field.NewPath("networking", "clusterNetwork").Index(0).Child("hostPrefix"), 23, "hostPrefix is too small"),
+		field.Required(field.NewPath("controlPlane"), ""),
+	})
+	expected := `install-config.yaml:22:5: networking.clusterNetwork[0].hostPrefix: Invalid value: 23: hostPrefix is too small
+   22 |     hostPrefix: 23
+      |     ^
+install-config.yaml: controlPlane: Required value`
+	assert.EqualError(t, err, expected)
+}